@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"     // Пакет для форматирования сообщений об ошибках
+	"hash"    // Пакет с общим интерфейсом хеш-функций
+	"strings" // Пакет для работы со строками
+
+	"github.com/ftomza/gogost/gost34112012256" // ГОСТ Р 34.11-2012 (Стрибог-256)
+	"github.com/ftomza/gogost/gost34112012512" // ГОСТ Р 34.11-2012 (Стрибог-512)
+
+	"main/gost341194" // Импорт пакета с реализацией ГОСТ Р 34.11-94
+)
+
+// Algorithm описывает хеш-функцию, доступную в командной строке и веб-интерфейсе
+type Algorithm struct {
+	Name  string           // Имя для выбора алгоритма (флаг -a, поле формы)
+	Title string           // Название, выводимое рядом с хеш-значением
+	New   func() hash.Hash // Создает новый экземпляр хеш-функции
+}
+
+// Поддерживаемые хеш-функции; первая используется по умолчанию
+var algorithms = []Algorithm{
+	{
+		Name:  "gost94-test",
+		Title: "ГОСТ Р 34.11-94 (тестовые параметры)",
+		New:   func() hash.Hash { return gost341194.New(gost341194.SboxDefault) },
+	},
+	{
+		Name:  "gost94-cryptopro",
+		Title: "ГОСТ Р 34.11-94 (параметры CryptoPro)",
+		New: func() hash.Hash {
+			return gost341194.New(&gost341194.SboxIdGostR341194CryptoProParamSet)
+		},
+	},
+	{
+		Name:  "streebog256",
+		Title: "ГОСТ Р 34.11-2012 (Стрибог-256)",
+		New:   gost34112012256.New,
+	},
+	{
+		Name:  "streebog512",
+		Title: "ГОСТ Р 34.11-2012 (Стрибог-512)",
+		New:   gost34112012512.New,
+	},
+}
+
+// algorithmNames возвращает имена поддерживаемых алгоритмов через запятую
+func algorithmNames() string {
+	names := make([]string, len(algorithms))
+	for i, a := range algorithms {
+		names[i] = a.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+// algorithmByName ищет алгоритм по имени без учета регистра; пустое имя
+// означает алгоритм по умолчанию
+func algorithmByName(name string) (*Algorithm, error) {
+	if name == "" {
+		return &algorithms[0], nil
+	}
+	for i := range algorithms {
+		if strings.EqualFold(algorithms[i].Name, name) {
+			return &algorithms[i], nil
+		}
+	}
+	return nil, fmt.Errorf("неизвестный алгоритм %q (доступны: %s)", name, algorithmNames())
+}
@@ -0,0 +1,233 @@
+package main
+
+import (
+	"flag"     // Пакет для разбора флагов командной строки
+	"fmt"      // Пакет для форматированного ввода-вывода
+	"io"       // Пакет для работы с операциями ввода-вывода
+	"net/http" // Пакет для создания HTTP сервера
+	"os"       // Пакет для работы с операционной системой
+	"strings"  // Пакет для работы со строками
+	"time"     // Пакет для измерения времени
+
+	"main/gost34102001" // Подпись ГОСТ Р 34.10-2001
+	"main/gost341194"   // Импорт пакета с реализацией ГОСТ Р 34.11-94
+)
+
+const programName = "gost94" // Имя программы в справке
+
+// command - подкоманда командной строки
+type command struct {
+	name    string                  // Имя подкоманды
+	summary string                  // Краткое описание для справки
+	run     func(args []string) int // Выполняет подкоманду и возвращает код завершения
+}
+
+// commands - подкоманды в порядке вывода в справке
+var commands = []command{
+	{"hash", "вычислить хеш-значения файлов", cmdHash},
+	{"check", "проверить файлы по спискам контрольных сумм", cmdCheck},
+	{"serve", "запустить веб-интерфейс", cmdServe},
+	{"bench", "измерить скорость алгоритмов хеширования", cmdBench},
+	{"selftest", "проверить реализацию на известных ответах", cmdSelftest},
+	{"sign", "подписать файл (ГОСТ Р 34.10-2001)", cmdSign},
+	{"verify", "проверить подпись файла", cmdVerify},
+	{"keygen", "создать ключевую пару", cmdKeygen},
+	{"pubkey", "получить открытый ключ из закрытого", cmdPubkey},
+	{"thumbprint", "вычислить отпечатки сертификатов", cmdThumbprint},
+}
+
+// commandByName возвращает подкоманду по имени или nil
+func commandByName(name string) *command {
+	for i := range commands {
+		if commands[i].name == name {
+			return &commands[i]
+		}
+	}
+	return nil
+}
+
+// printUsage выводит общую справку со списком подкоманд
+func printUsage(w io.Writer) {
+	fmt.Fprintf(w, "Использование: %s <команда> [флаги] [аргументы]\n\nКоманды:\n", programName)
+	for _, c := range commands {
+		fmt.Fprintf(w, "  %-12s %s\n", c.name, c.summary)
+	}
+	fmt.Fprintf(w, "\nСправка по команде: %s <команда> -help\n", programName)
+}
+
+// newFlagSet создает набор флагов подкоманды; справка (-h, -help, --help)
+// выводит строку использования usage и описание флагов
+func newFlagSet(name, usage string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Использование: %s %s\n", programName, usage)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// cmdHash выводит хеш-значения файлов; без аргументов хешируется
+// стандартный ввод. Возвращает код завершения процесса
+func cmdHash(args []string) int {
+	fs := newFlagSet("hash", "hash [-a <алгоритм>] [-r] [-L] [-hidden] [-format text|sum] [-b] [<файл|шаблон|каталог>...]\n"+
+		"       "+programName+" hash -c [--quiet] [--status] [--strict] [--ignore-missing] [<список>...]")
+	algName := fs.String("a", algorithms[0].Name, "алгоритм хеширования: "+algorithmNames())
+	var opt walkOptions
+	fs.BoolVar(&opt.recursive, "r", false, "рекурсивно хешировать файлы в каталогах")
+	fs.BoolVar(&opt.follow, "L", false, "следовать символическим ссылкам внутри каталогов")
+	fs.BoolVar(&opt.hidden, "hidden", false, "включать скрытые файлы и каталоги")
+	format := fs.String("format", "text", "формат вывода: text или sum (как coreutils: <хеш>  <файл>)")
+	var binary bool
+	fs.BoolVar(&binary, "b", false, "помечать файлы в формате sum как двоичные (*)")
+	fs.BoolVar(&binary, "binary", false, "то же, что -b")
+	var check bool
+	fs.BoolVar(&check, "c", false, "проверить файлы по спискам контрольных сумм (как команда check)")
+	fs.BoolVar(&check, "check", false, "то же, что -c")
+	var copt checkOptions
+	addCheckFlags(fs, &copt)
+	fs.Parse(args)
+
+	if *format != "text" && *format != "sum" {
+		fmt.Fprintf(os.Stderr, "Ошибка: неизвестный формат вывода %s\n", *format)
+		return exitUsage
+	}
+	alg, err := algorithmByName(*algName)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Ошибка: %v\n", err)
+		return exitUsage
+	}
+	files := fs.Args()
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+	if check {
+		return checkSums(files, alg, copt)
+	}
+	return hashFiles(files, alg, opt, *format == "sum", binary)
+}
+
+// cmdCheck проверяет файлы по спискам контрольных сумм; без аргументов
+// список читается со стандартного ввода. Возвращает код завершения процесса
+func cmdCheck(args []string) int {
+	fs := newFlagSet("check", "check [-a <алгоритм>] [--quiet] [--status] [--strict] [--ignore-missing] [<список>...]")
+	algName := fs.String("a", algorithms[0].Name, "алгоритм, которым построен список: "+algorithmNames())
+	var opt checkOptions
+	addCheckFlags(fs, &opt)
+	fs.Parse(args)
+
+	alg, err := algorithmByName(*algName)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Ошибка: %v\n", err)
+		return exitUsage
+	}
+	sumFiles := fs.Args()
+	if len(sumFiles) == 0 {
+		sumFiles = []string{"-"}
+	}
+	return checkSums(sumFiles, alg, opt)
+}
+
+// addCheckFlags добавляет флаги проверки контрольных сумм: они общие для
+// команды check и флага -c команды hash
+func addCheckFlags(fs *flag.FlagSet, opt *checkOptions) {
+	fs.BoolVar(&opt.quiet, "quiet", false, "при проверке не выводить OK для совпавших файлов")
+	fs.BoolVar(&opt.status, "status", false, "при проверке ничего не выводить, результат в коде завершения")
+	fs.BoolVar(&opt.strict, "strict", false, "при проверке считать ошибкой строки неверного формата")
+	fs.BoolVar(&opt.ignoreMissing, "ignore-missing", false, "при проверке пропускать отсутствующие файлы")
+}
+
+// cmdServe запускает веб-интерфейс. Возвращает код завершения процесса
+func cmdServe(args []string) int {
+	fs := newFlagSet("serve", "serve [-addr <адрес:порт>]")
+	addr := fs.String("addr", ":8080", "адрес и порт веб-сервера")
+	fs.Parse(args)
+	if fs.NArg() != 0 {
+		fs.Usage()
+		return exitUsage
+	}
+
+	url := "http://" + *addr
+	if strings.HasPrefix(*addr, ":") {
+		url = "http://localhost" + *addr
+	}
+	fmt.Printf("Запуск веб-сервера на %s\n", url)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", indexHandler)
+	mux.HandleFunc("/hash", hashTextHandler)
+	mux.HandleFunc("/hash-file", hashFileHandler)
+	mux.HandleFunc("/thumbprint", thumbprintHandler)
+
+	if err := http.ListenAndServe(*addr, mux); err != nil {
+		fmt.Fprintf(os.Stderr, "Ошибка запуска сервера: %v\n", err)
+		return exitError
+	}
+	return exitOK
+}
+
+// cmdBench измеряет скорость хеширования данных в памяти.
+// Возвращает код завершения процесса
+func cmdBench(args []string) int {
+	fs := newFlagSet("bench", "bench [-a <алгоритм>] [-size <МиБ>]")
+	algName := fs.String("a", "", "алгоритм (по умолчанию все): "+algorithmNames())
+	size := fs.Int("size", 16, "объем данных для каждого алгоритма в МиБ")
+	fs.Parse(args)
+	if fs.NArg() != 0 || *size <= 0 {
+		fs.Usage()
+		return exitUsage
+	}
+
+	algs := algorithms
+	if *algName != "" {
+		alg, err := algorithmByName(*algName)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Ошибка: %v\n", err)
+			return exitUsage
+		}
+		algs = []Algorithm{*alg}
+	}
+
+	buf := make([]byte, readBufferSize)
+	total := int64(*size) << 20
+	for _, alg := range algs {
+		h := alg.New()
+		start := time.Now()
+		for n := int64(0); n < total; n += int64(len(buf)) {
+			h.Write(buf)
+		}
+		h.Sum(nil)
+		elapsed := time.Since(start)
+		fmt.Printf("%-18s %8.2f МиБ/с (%d МиБ за %v)\n", alg.Name,
+			float64(*size)/elapsed.Seconds(), *size, elapsed.Round(time.Millisecond))
+	}
+	return exitOK
+}
+
+// cmdSelftest запускает самопроверки пакетов на известных ответах.
+// Возвращает код завершения процесса
+func cmdSelftest(args []string) int {
+	fs := newFlagSet("selftest", "selftest")
+	fs.Parse(args)
+	if fs.NArg() != 0 {
+		fs.Usage()
+		return exitUsage
+	}
+
+	tests := []struct {
+		name string
+		run  func() error
+	}{
+		{"ГОСТ Р 34.11-94", gost341194.SelfTest},
+		{"ГОСТ Р 34.10-2001", gost34102001.SelfTest},
+	}
+	code := exitOK
+	for _, t := range tests {
+		if err := t.run(); err != nil {
+			fmt.Fprintf(os.Stderr, "%s: ОШИБКА: %v\n", t.name, err)
+			code = exitError
+			continue
+		}
+		fmt.Printf("%s: OK\n", t.name)
+	}
+	return code
+}
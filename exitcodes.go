@@ -0,0 +1,9 @@
+package main
+
+// Коды завершения процесса в режиме командной строки
+const (
+	exitOK       = 0 // Успешное завершение
+	exitError    = 1 // Ошибка ввода-вывода или обработки данных
+	exitUsage    = 2 // Неверные аргументы командной строки
+	exitMismatch = 3 // Хеш-значение или подпись не совпали
+)
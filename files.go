@@ -0,0 +1,158 @@
+package main
+
+import (
+	"fmt"           // Пакет для форматирования сообщений об ошибках
+	"os"            // Пакет для работы с операционной системой
+	"path/filepath" // Пакет для работы с путями и шаблонами имен файлов
+	"sort"          // Пакет для сортировки
+	"strings"       // Пакет для работы со строками
+)
+
+// walkOptions задает правила обхода аргументов командной строки
+type walkOptions struct {
+	recursive bool // Обходить каталоги рекурсивно (-r)
+	follow    bool // Следовать символическим ссылкам внутри каталогов (-L)
+	hidden    bool // Включать скрытые файлы и каталоги, имена которых начинаются с точки
+}
+
+// fileEntry - файл для хеширования или ошибка, возникшая при его поиске
+type fileEntry struct {
+	path string
+	err  error
+}
+
+// fileCollector собирает файлы в порядке вывода
+type fileCollector struct {
+	opt     walkOptions
+	entries []fileEntry
+}
+
+// isHidden сообщает, является ли имя файла скрытым
+func isHidden(name string) bool {
+	return len(name) > 1 && strings.HasPrefix(name, ".") && name != ".."
+}
+
+// collectFiles раскрывает аргументы командной строки в список файлов:
+// шаблоны (*, ?, [...]) раскрываются независимо от командной оболочки,
+// каталоги при recursive обходятся рекурсивно. Файлы каждого аргумента идут
+// в порядке сортировки путей; ошибки не прерывают обход и попадают в список
+// на место файла, при поиске которого возникли
+func collectFiles(args []string, opt walkOptions) []fileEntry {
+	c := &fileCollector{opt: opt}
+	for _, arg := range args {
+		if arg == "-" || !strings.ContainsAny(arg, "*?[") {
+			c.addArg(arg)
+			continue
+		}
+		matches, err := filepath.Glob(arg)
+		if err != nil {
+			c.add(arg, fmt.Errorf("неверный шаблон %s: %w", arg, err))
+			continue
+		}
+		// Имя со спецсимволами может быть именем существующего файла
+		if len(matches) == 0 {
+			if _, err := os.Lstat(arg); err == nil {
+				matches = []string{arg}
+			}
+		}
+		// Как и командная оболочка, шаблон не выбирает скрытые файлы,
+		// если сам не начинается с точки
+		patternHidden := strings.HasPrefix(filepath.Base(arg), ".")
+		n := 0
+		for _, m := range matches {
+			if opt.hidden || patternHidden || !isHidden(filepath.Base(m)) {
+				matches[n] = m
+				n++
+			}
+		}
+		matches = matches[:n]
+		if len(matches) == 0 {
+			c.add(arg, fmt.Errorf("нет файлов, соответствующих шаблону %s", arg))
+			continue
+		}
+		sort.Strings(matches)
+		for _, m := range matches {
+			c.addArg(m)
+		}
+	}
+	return c.entries
+}
+
+// add добавляет файл или ошибку в список
+func (c *fileCollector) add(path string, err error) {
+	c.entries = append(c.entries, fileEntry{path: path, err: err})
+}
+
+// addArg добавляет путь из командной строки. Символическая ссылка в аргументе
+// всегда разыменовывается; специальные файлы (устройства, каналы) допускаются
+func (c *fileCollector) addArg(path string) {
+	if path == "-" {
+		c.add(path, nil)
+		return
+	}
+	info, err := os.Stat(path)
+	switch {
+	case err != nil:
+		c.add(path, err)
+	case !info.IsDir():
+		c.add(path, nil)
+	case !c.opt.recursive:
+		c.add(path, fmt.Errorf("%s является каталогом (используйте -r)", path))
+	default:
+		c.walk(path, []os.FileInfo{info})
+	}
+}
+
+// walk рекурсивно добавляет обычные файлы каталога dir в порядке имен.
+// parents - каталоги на пути от аргумента до dir для обнаружения циклов
+// из символических ссылок
+func (c *fileCollector) walk(dir string, parents []os.FileInfo) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		c.add(dir, err)
+		// ReadDir возвращает записи, прочитанные до ошибки
+	}
+	for _, e := range entries {
+		if !c.opt.hidden && isHidden(e.Name()) {
+			continue
+		}
+		path := filepath.Join(dir, e.Name())
+
+		var info os.FileInfo
+		var err error
+		if e.Type()&os.ModeSymlink != 0 {
+			if !c.opt.follow {
+				continue
+			}
+			info, err = os.Stat(path)
+		} else {
+			info, err = e.Info()
+		}
+		if err != nil {
+			c.add(path, err)
+			continue
+		}
+
+		switch {
+		case info.IsDir():
+			if loopsTo(info, parents) {
+				c.add(path, fmt.Errorf("%s: цикл символических ссылок", path))
+				continue
+			}
+			c.walk(path, append(parents[:len(parents):len(parents)], info))
+		case info.Mode().IsRegular():
+			c.add(path, nil)
+		}
+		// Устройства, каналы и сокеты внутри каталогов пропускаются
+	}
+}
+
+// loopsTo сообщает, совпадает ли каталог info с одним из parents
+func loopsTo(info os.FileInfo, parents []os.FileInfo) bool {
+	for _, p := range parents {
+		if os.SameFile(info, p) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,61 @@
+package gost34102001
+
+import (
+	"encoding/asn1" // Пакет для кодирования структур ASN.1 DER
+	"fmt"           // Пакет для форматирования сообщений об ошибках
+	"strings"       // Пакет для работы со строками
+
+	"github.com/ftomza/gogost/gost3410" // Эллиптические кривые ГОСТ Р 34.10
+)
+
+// Curve описывает именованный набор параметров эллиптической кривой
+type Curve struct {
+	Name  string                 // Короткое имя набора для командной строки
+	OID   asn1.ObjectIdentifier  // Идентификатор объекта ASN.1
+	Curve func() *gost3410.Curve // Создает параметры кривой
+}
+
+// Наборы параметров ГОСТ Р 34.10-2001 (RFC 4357); первый используется
+// по умолчанию
+var curves = []Curve{
+	// id-GostR3410-2001-CryptoPro-A-ParamSet
+	{Name: "cryptopro-a", OID: asn1.ObjectIdentifier{1, 2, 643, 2, 2, 35, 1}, Curve: gost3410.CurveIdGostR34102001CryptoProAParamSet},
+	// id-GostR3410-2001-CryptoPro-B-ParamSet
+	{Name: "cryptopro-b", OID: asn1.ObjectIdentifier{1, 2, 643, 2, 2, 35, 2}, Curve: gost3410.CurveIdGostR34102001CryptoProBParamSet},
+	// id-GostR3410-2001-CryptoPro-C-ParamSet
+	{Name: "cryptopro-c", OID: asn1.ObjectIdentifier{1, 2, 643, 2, 2, 35, 3}, Curve: gost3410.CurveIdGostR34102001CryptoProCParamSet},
+	// id-GostR3410-2001-CryptoPro-XchA-ParamSet
+	{Name: "cryptopro-xcha", OID: asn1.ObjectIdentifier{1, 2, 643, 2, 2, 36, 0}, Curve: gost3410.CurveIdGostR34102001CryptoProXchAParamSet},
+	// id-GostR3410-2001-CryptoPro-XchB-ParamSet
+	{Name: "cryptopro-xchb", OID: asn1.ObjectIdentifier{1, 2, 643, 2, 2, 36, 1}, Curve: gost3410.CurveIdGostR34102001CryptoProXchBParamSet},
+	// id-GostR3410-2001-TestParamSet (пример из ГОСТ Р 34.10-2001 и RFC 5832)
+	{Name: "test", OID: asn1.ObjectIdentifier{1, 2, 643, 2, 2, 35, 0}, Curve: gost3410.CurveIdGostR34102001TestParamSet},
+}
+
+// Curves возвращает список всех поддерживаемых наборов параметров кривых
+func Curves() []Curve {
+	return append([]Curve(nil), curves...)
+}
+
+// CurveByName ищет набор параметров кривой по имени без учета регистра
+func CurveByName(name string) (*Curve, error) {
+	for i := range curves {
+		if strings.EqualFold(curves[i].Name, name) {
+			c := curves[i]
+			return &c, nil
+		}
+	}
+	return nil, fmt.Errorf("gost34102001: неизвестный набор параметров кривой %q", name)
+}
+
+// CurveByOID ищет набор параметров кривой по идентификатору объекта в
+// точечной записи
+func CurveByOID(oid string) (*Curve, error) {
+	for i := range curves {
+		if curves[i].OID.String() == oid {
+			c := curves[i]
+			return &c, nil
+		}
+	}
+	return nil, fmt.Errorf("gost34102001: неизвестный OID набора параметров кривой %s", oid)
+}
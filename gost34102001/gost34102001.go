@@ -0,0 +1,93 @@
+// Пакет gost34102001 формирует и проверяет электронную подпись ГОСТ Р 34.10-2001
+// для хеш-значений ГОСТ Р 34.11-94 с набором параметров CryptoPro (RFC 4357).
+// Хеш-значение рассматривается как число, младший байт которого идет первым,
+// подпись имеет вид s || r (RFC 4490)
+package gost34102001
+
+import (
+	"errors" // Пакет для создания ошибок
+	"io"     // Пакет для работы с операциями ввода-вывода
+
+	"github.com/ftomza/gogost/gost3410" // Эллиптические кривые ГОСТ Р 34.10
+
+	"main/gost341194" // Хеш-функция ГОСТ Р 34.11-94
+)
+
+const SignatureSize = 64 // Размер подписи в байтах (s и r по 256 бит)
+
+var (
+	errDigestSize    = errors.New("gost34102001: неверный размер хеш-значения")
+	errSignatureSize = errors.New("gost34102001: неверный размер подписи")
+	errBadSignature  = errors.New("gost34102001: подпись неверна")
+)
+
+// NewHash создает хеш-функцию ГОСТ Р 34.11-94 с набором параметров CryptoPro,
+// используемую вместе с ГОСТ Р 34.10-2001
+func NewHash() *gost341194.Hash {
+	return gost341194.New(&gost341194.SboxIdGostR341194CryptoProParamSet)
+}
+
+// Digest вычисляет хеш-значение данных из r для подписи
+func Digest(r io.Reader) ([]byte, error) {
+	h := NewHash()
+	if _, err := io.Copy(h, r); err != nil {
+		return nil, err
+	}
+	return h.Sum(nil), nil
+}
+
+// reversed возвращает копию b с обратным порядком байтов. Хеш-значение
+// переводится так в число со старшим байтом первым, как его ожидает gost3410
+func reversed(b []byte) []byte {
+	r := make([]byte, len(b))
+	for i := range b {
+		r[i] = b[len(b)-1-i]
+	}
+	return r
+}
+
+// SignDigest подписывает хеш-значение digest закрытым ключом prv.
+// rand - источник случайного числа k
+func SignDigest(prv *gost3410.PrivateKey, digest []byte, rand io.Reader) ([]byte, error) {
+	if len(digest) != gost341194.Size {
+		return nil, errDigestSize
+	}
+	return prv.SignDigest(reversed(digest), rand)
+}
+
+// VerifyDigest проверяет подпись sig хеш-значения digest открытым ключом pub.
+// Возвращает nil, если подпись верна
+func VerifyDigest(pub *gost3410.PublicKey, digest, sig []byte) error {
+	if len(digest) != gost341194.Size {
+		return errDigestSize
+	}
+	if len(sig) != SignatureSize {
+		return errSignatureSize
+	}
+	ok, err := pub.VerifyDigest(reversed(digest), sig)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errBadSignature
+	}
+	return nil
+}
+
+// Sign хеширует данные из r и подписывает хеш-значение закрытым ключом prv
+func Sign(prv *gost3410.PrivateKey, r io.Reader, rand io.Reader) ([]byte, error) {
+	digest, err := Digest(r)
+	if err != nil {
+		return nil, err
+	}
+	return SignDigest(prv, digest, rand)
+}
+
+// Verify хеширует данные из r и проверяет их подпись sig открытым ключом pub
+func Verify(pub *gost3410.PublicKey, r io.Reader, sig []byte) error {
+	digest, err := Digest(r)
+	if err != nil {
+		return err
+	}
+	return VerifyDigest(pub, digest, sig)
+}
@@ -0,0 +1,95 @@
+package gost34102001
+
+import (
+	"bytes"        // Пакет для сравнения байтовых срезов
+	"crypto/rand"  // Пакет с криптографически стойким генератором случайных чисел
+	"encoding/hex" // Пакет для кодирования/декодирования шестнадцатеричных строк
+	"fmt"          // Пакет для форматирования чисел
+	"testing"      // Пакет для написания тестов
+)
+
+// Пример из ГОСТ Р 34.10-2001 (RFC 5832, раздел 7.1): открытый ключ и
+// подпись с известным случайным числом k
+func TestRFC5832(t *testing.T) {
+	prv, digest, k, err := rfc5832Example()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub, err := prv.PublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if x, y := fmt.Sprintf("%064x", pub.X), fmt.Sprintf("%064x", pub.Y); x != rfc5832X || y != rfc5832Y {
+		t.Errorf("открытый ключ (%s, %s), ожидалось (%s, %s)", x, y, rfc5832X, rfc5832Y)
+	}
+
+	want, _ := hex.DecodeString(rfc5832S + rfc5832R)
+	sig, err := SignDigest(prv, digest, bytes.NewReader(k))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(sig, want) {
+		t.Fatalf("подпись %x, ожидалась %x", sig, want)
+	}
+	if err := VerifyDigest(pub, digest, sig); err != nil {
+		t.Errorf("подпись из примера отвергнута: %v", err)
+	}
+}
+
+// Искаженные подпись и хеш-значение отвергаются
+func TestVerifyRejectsTampering(t *testing.T) {
+	prv, digest, k, err := rfc5832Example()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub, _ := prv.PublicKey()
+	sig, err := SignDigest(prv, digest, bytes.NewReader(k))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < SignatureSize; i += 7 {
+		bad := append([]byte(nil), sig...)
+		bad[i] ^= 1
+		if VerifyDigest(pub, digest, bad) == nil {
+			t.Errorf("принята подпись с искаженным байтом %d", i)
+		}
+	}
+	bad := append([]byte(nil), digest...)
+	bad[0] ^= 1
+	if VerifyDigest(pub, bad, sig) == nil {
+		t.Error("подпись принята для другого хеш-значения")
+	}
+	if VerifyDigest(pub, digest, sig[:SignatureSize-1]) == nil {
+		t.Error("принята подпись неверной длины")
+	}
+}
+
+// Sign и Verify хешируют сообщение ГОСТ Р 34.11-94 с параметрами CryptoPro
+func TestSignVerify(t *testing.T) {
+	for _, c := range Curves() {
+		t.Run(c.Name, func(t *testing.T) {
+			prv, err := GenerateKey(&c, rand.Reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+			pub, _ := prv.PublicKey()
+			msg := []byte("Suppose the original message has length = 50 bytes")
+			sig, err := Sign(prv, bytes.NewReader(msg), rand.Reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := Verify(pub, bytes.NewReader(msg), sig); err != nil {
+				t.Errorf("подпись отвергнута: %v", err)
+			}
+			if Verify(pub, bytes.NewReader(msg[1:]), sig) == nil {
+				t.Error("подпись принята для другого сообщения")
+			}
+		})
+	}
+}
+
+func TestSelfTest(t *testing.T) {
+	if err := SelfTest(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,95 @@
+package gost34102001
+
+import (
+	"encoding/pem" // Пакет для кодирования данных в формате PEM
+	"errors"       // Пакет для создания ошибок
+	"io"           // Пакет для работы с операциями ввода-вывода
+
+	"github.com/ftomza/gogost/gost3410" // Эллиптические кривые ГОСТ Р 34.10
+)
+
+// Типы блоков PEM
+const (
+	pemPublicKey           = "PUBLIC KEY"
+	pemPrivateKey          = "PRIVATE KEY"
+	pemEncryptedPrivateKey = "ENCRYPTED PRIVATE KEY"
+)
+
+var (
+	errPEM         = errors.New("gost34102001: данные не содержат ключа в формате PEM")
+	errNoPassword  = errors.New("gost34102001: закрытый ключ защищен паролем")
+	errPEMKeyLabel = errors.New("gost34102001: неподдерживаемый тип блока PEM")
+)
+
+// GenerateKey вырабатывает закрытый ключ на кривой c, используя rand
+// как источник случайных чисел
+func GenerateKey(c *Curve, rand io.Reader) (*gost3410.PrivateKey, error) {
+	curve := c.Curve()
+	for {
+		prv, err := gost3410.GenPrivateKey(curve, rand)
+		if err != nil {
+			return nil, err
+		}
+		// Ключ должен быть меньше порядка подгруппы точек q
+		if prv.Key.Cmp(curve.Q) < 0 {
+			return prv, nil
+		}
+	}
+}
+
+// EncodePublicKeyPEM кодирует открытый ключ в блок PEM "PUBLIC KEY"
+func EncodePublicKeyPEM(pub *gost3410.PublicKey) ([]byte, error) {
+	der, err := MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: pemPublicKey, Bytes: der}), nil
+}
+
+// DecodePublicKeyPEM разбирает открытый ключ из блока PEM "PUBLIC KEY"
+func DecodePublicKeyPEM(data []byte) (*gost3410.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errPEM
+	}
+	if block.Type != pemPublicKey {
+		return nil, errPEMKeyLabel
+	}
+	return ParsePKIXPublicKey(block.Bytes)
+}
+
+// EncodePrivateKeyPEM кодирует закрытый ключ в блок PEM "PRIVATE KEY" или,
+// если задан пароль, "ENCRYPTED PRIVATE KEY"
+func EncodePrivateKeyPEM(prv *gost3410.PrivateKey, password []byte, rand io.Reader) ([]byte, error) {
+	if len(password) == 0 {
+		der, err := MarshalPKCS8PrivateKey(prv)
+		if err != nil {
+			return nil, err
+		}
+		return pem.EncodeToMemory(&pem.Block{Type: pemPrivateKey, Bytes: der}), nil
+	}
+	der, err := EncryptPKCS8PrivateKey(prv, password, rand)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: pemEncryptedPrivateKey, Bytes: der}), nil
+}
+
+// DecodePrivateKeyPEM разбирает закрытый ключ из блока PEM "PRIVATE KEY" или
+// "ENCRYPTED PRIVATE KEY"; для второго требуется пароль
+func DecodePrivateKeyPEM(data, password []byte) (*gost3410.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errPEM
+	}
+	switch block.Type {
+	case pemPrivateKey:
+		return ParsePKCS8PrivateKey(block.Bytes)
+	case pemEncryptedPrivateKey:
+		if len(password) == 0 {
+			return nil, errNoPassword
+		}
+		return DecryptPKCS8PrivateKey(block.Bytes, password)
+	}
+	return nil, errPEMKeyLabel
+}
@@ -0,0 +1,344 @@
+package gost34102001
+
+import (
+	"crypto/x509/pkix" // Пакет со структурой AlgorithmIdentifier
+	"encoding/asn1"    // Пакет для кодирования структур ASN.1 DER
+	"errors"           // Пакет для создания ошибок
+	"fmt"              // Пакет для форматирования сообщений об ошибках
+	"io"               // Пакет для работы с операциями ввода-вывода
+	"math/big"         // Пакет для работы с большими числами
+
+	"github.com/ftomza/gogost/gost28147" // Блочный шифр ГОСТ 28147-89
+	"github.com/ftomza/gogost/gost3410"  // Эллиптические кривые ГОСТ Р 34.10
+
+	"main/gost341194" // Хеш-функция ГОСТ Р 34.11-94
+)
+
+// Идентификаторы объектов ASN.1
+var (
+	oidGostR34102001   = asn1.ObjectIdentifier{1, 2, 643, 2, 2, 19}         // id-GostR3410-2001
+	oidPBES2           = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 13} // id-PBES2
+	oidPBKDF2          = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 12} // id-PBKDF2
+	oidHMACGostR341194 = asn1.ObjectIdentifier{1, 2, 643, 2, 2, 10}         // id-HMACGostR3411-94
+	oidGost2814789     = asn1.ObjectIdentifier{1, 2, 643, 2, 2, 21}         // id-Gost28147-89
+	oidCipherParamSetA = asn1.ObjectIdentifier{1, 2, 643, 2, 2, 31, 1}      // id-Gost28147-89-CryptoPro-A-ParamSet
+	oidCipherParamSetB = asn1.ObjectIdentifier{1, 2, 643, 2, 2, 31, 2}      // id-Gost28147-89-CryptoPro-B-ParamSet
+	oidCipherParamSetC = asn1.ObjectIdentifier{1, 2, 643, 2, 2, 31, 3}      // id-Gost28147-89-CryptoPro-C-ParamSet
+	oidCipherParamSetD = asn1.ObjectIdentifier{1, 2, 643, 2, 2, 31, 4}      // id-Gost28147-89-CryptoPro-D-ParamSet
+	oidCipherParamSetZ = asn1.ObjectIdentifier{1, 2, 643, 7, 1, 2, 5, 1, 1} // id-tc26-gost-28147-param-Z
+)
+
+// Параметры защиты закрытого ключа паролем
+const (
+	pbkdf2Iter    = 2000    // Число итераций PBKDF2 (не менее 2000 по рекомендациям ТК 26)
+	pbkdf2MaxIter = 1 << 20 // Наибольшее число итераций PBKDF2 в принимаемом ключе
+	pbkdf2SaltLen = 16      // Размер соли в байтах
+	cipherKeyLen  = 32      // Размер ключа ГОСТ 28147-89 в байтах
+	cipherIVLen   = 8       // Размер синхропосылки ГОСТ 28147-89 в байтах
+)
+
+var (
+	errKeyAlgorithm = errors.New("gost34102001: ключ не является ключом ГОСТ Р 34.10-2001")
+	errKeyDigest    = errors.New("gost34102001: поддерживается только хеш-функция с параметрами CryptoPro")
+	errKeyFormat    = errors.New("gost34102001: неверный формат ключа")
+	errKeyRange     = errors.New("gost34102001: закрытый ключ вне допустимого диапазона")
+	errEncryption   = errors.New("gost34102001: неподдерживаемый алгоритм защиты ключа")
+	errPassword     = errors.New("gost34102001: неверный пароль или поврежденный ключ")
+)
+
+// publicKeyParams - GostR3410-2001-PublicKeyParameters (RFC 4491)
+type publicKeyParams struct {
+	PublicKeyParamSet asn1.ObjectIdentifier
+	DigestParamSet    asn1.ObjectIdentifier
+}
+
+// subjectPublicKeyInfo - SubjectPublicKeyInfo (RFC 5280)
+type subjectPublicKeyInfo struct {
+	Algorithm pkix.AlgorithmIdentifier
+	PublicKey asn1.BitString
+}
+
+// privateKeyInfo - PrivateKeyInfo (PKCS#8, RFC 5208)
+type privateKeyInfo struct {
+	Version    int
+	Algorithm  pkix.AlgorithmIdentifier
+	PrivateKey []byte
+}
+
+// encryptedPrivateKeyInfo - EncryptedPrivateKeyInfo (PKCS#8, RFC 5208)
+type encryptedPrivateKeyInfo struct {
+	Algorithm     pkix.AlgorithmIdentifier
+	EncryptedData []byte
+}
+
+// pbes2Params - PBES2-params (RFC 8018)
+type pbes2Params struct {
+	KeyDerivationFunc pkix.AlgorithmIdentifier
+	EncryptionScheme  pkix.AlgorithmIdentifier
+}
+
+// pbkdf2Params - PBKDF2-params (RFC 8018); prf указывается явно, так как
+// по умолчанию подразумевается HMAC-SHA1
+type pbkdf2Params struct {
+	Salt           []byte
+	IterationCount int
+	KeyLength      int `asn1:"optional"`
+	PRF            pkix.AlgorithmIdentifier
+}
+
+// gost28147Params - Gost28147-89-Parameters (RFC 4357)
+type gost28147Params struct {
+	IV                 []byte
+	EncryptionParamSet asn1.ObjectIdentifier
+}
+
+// cipherSboxes сопоставляет наборам параметров ГОСТ 28147-89 их S-блоки
+var cipherSboxes = []struct {
+	oid  asn1.ObjectIdentifier
+	sbox *gost28147.Sbox
+}{
+	{oidCipherParamSetA, &gost28147.SboxIdGost2814789CryptoProAParamSet},
+	{oidCipherParamSetB, &gost28147.SboxIdGost2814789CryptoProBParamSet},
+	{oidCipherParamSetC, &gost28147.SboxIdGost2814789CryptoProCParamSet},
+	{oidCipherParamSetD, &gost28147.SboxIdGost2814789CryptoProDParamSet},
+	{oidCipherParamSetZ, &gost28147.SboxIdtc26gost28147paramZ},
+}
+
+// curveOf находит набор параметров, к которому относится кривая c
+func curveOf(c *gost3410.Curve) (*Curve, error) {
+	for i := range curves {
+		if curves[i].Curve().Name == c.Name {
+			cv := curves[i]
+			return &cv, nil
+		}
+	}
+	return nil, fmt.Errorf("gost34102001: кривая %s не поддерживается", c.Name)
+}
+
+// keyAlgorithm формирует AlgorithmIdentifier ключа на кривой c
+func keyAlgorithm(c *gost3410.Curve) (pkix.AlgorithmIdentifier, error) {
+	curve, err := curveOf(c)
+	if err != nil {
+		return pkix.AlgorithmIdentifier{}, err
+	}
+	digest, err := gost341194.ParamSetByName("cryptopro")
+	if err != nil {
+		return pkix.AlgorithmIdentifier{}, err
+	}
+	params, err := asn1.Marshal(publicKeyParams{PublicKeyParamSet: curve.OID, DigestParamSet: digest.OID})
+	if err != nil {
+		return pkix.AlgorithmIdentifier{}, err
+	}
+	return pkix.AlgorithmIdentifier{Algorithm: oidGostR34102001, Parameters: asn1.RawValue{FullBytes: params}}, nil
+}
+
+// parseKeyAlgorithm проверяет AlgorithmIdentifier ключа и возвращает кривую
+func parseKeyAlgorithm(alg *pkix.AlgorithmIdentifier) (*gost3410.Curve, error) {
+	if !alg.Algorithm.Equal(oidGostR34102001) {
+		return nil, errKeyAlgorithm
+	}
+	var params publicKeyParams
+	if rest, err := asn1.Unmarshal(alg.Parameters.FullBytes, &params); err != nil || len(rest) != 0 {
+		return nil, errKeyFormat
+	}
+	if digest, err := gost341194.ParamSetByOID(params.DigestParamSet.String()); err != nil || digest.Name != "cryptopro" {
+		return nil, errKeyDigest
+	}
+	curve, err := CurveByOID(params.PublicKeyParamSet.String())
+	if err != nil {
+		return nil, err
+	}
+	return curve.Curve(), nil
+}
+
+// MarshalPKIXPublicKey кодирует открытый ключ в SubjectPublicKeyInfo (DER)
+// с параметрами кривой и хеш-функции CryptoPro (RFC 4491)
+func MarshalPKIXPublicKey(pub *gost3410.PublicKey) ([]byte, error) {
+	alg, err := keyAlgorithm(pub.C)
+	if err != nil {
+		return nil, err
+	}
+	// Координаты x и y младшим байтом вперед, обернутые в OCTET STRING
+	key, err := asn1.Marshal(pub.Raw())
+	if err != nil {
+		return nil, err
+	}
+	return asn1.Marshal(subjectPublicKeyInfo{
+		Algorithm: alg,
+		PublicKey: asn1.BitString{Bytes: key, BitLength: 8 * len(key)},
+	})
+}
+
+// ParsePKIXPublicKey разбирает открытый ключ из SubjectPublicKeyInfo (DER)
+func ParsePKIXPublicKey(der []byte) (*gost3410.PublicKey, error) {
+	var info subjectPublicKeyInfo
+	if rest, err := asn1.Unmarshal(der, &info); err != nil || len(rest) != 0 {
+		return nil, errKeyFormat
+	}
+	curve, err := parseKeyAlgorithm(&info.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+	var raw []byte
+	if rest, err := asn1.Unmarshal(info.PublicKey.RightAlign(), &raw); err != nil || len(rest) != 0 {
+		return nil, errKeyFormat
+	}
+	return gost3410.NewPublicKey(curve, raw)
+}
+
+// MarshalPKCS8PrivateKey кодирует закрытый ключ в PrivateKeyInfo (DER).
+// Ключ записывается в OCTET STRING младшим байтом вперед
+func MarshalPKCS8PrivateKey(prv *gost3410.PrivateKey) ([]byte, error) {
+	alg, err := keyAlgorithm(prv.C)
+	if err != nil {
+		return nil, err
+	}
+	key, err := asn1.Marshal(prv.Raw())
+	if err != nil {
+		return nil, err
+	}
+	return asn1.Marshal(privateKeyInfo{Algorithm: alg, PrivateKey: key})
+}
+
+// ParsePKCS8PrivateKey разбирает закрытый ключ из PrivateKeyInfo (DER).
+// Кроме OCTET STRING принимается ключ в виде INTEGER, как его записывают
+// старые версии OpenSSL
+func ParsePKCS8PrivateKey(der []byte) (*gost3410.PrivateKey, error) {
+	var info privateKeyInfo
+	if rest, err := asn1.Unmarshal(der, &info); err != nil || len(rest) != 0 {
+		return nil, errKeyFormat
+	}
+	curve, err := parseKeyAlgorithm(&info.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+	var raw []byte
+	if _, err := asn1.Unmarshal(info.PrivateKey, &raw); err != nil {
+		var n *big.Int
+		if _, err := asn1.Unmarshal(info.PrivateKey, &n); err != nil || n.Sign() <= 0 || n.BitLen() > 8*curve.PointSize() {
+			return nil, errKeyFormat
+		}
+		raw = reversed(n.FillBytes(make([]byte, curve.PointSize())))
+	}
+	prv, err := gost3410.NewPrivateKey(curve, raw)
+	if err != nil {
+		return nil, err
+	}
+	if prv.Key.Cmp(curve.Q) >= 0 {
+		return nil, errKeyRange
+	}
+	return prv, nil
+}
+
+// EncryptPKCS8PrivateKey кодирует закрытый ключ в EncryptedPrivateKeyInfo
+// (DER), защищенный паролем по схеме PBES2: ключ шифрования вырабатывается
+// PBKDF2 с HMAC-GOSTR3411-94 (параметры CryptoPro), ключ шифруется
+// ГОСТ 28147-89 в режиме гаммирования с обратной связью (набор CryptoPro-A).
+// Закрытый ключ короче 1024 байтов, поэтому преобразование ключа (key
+// meshing, RFC 4357) не выполняется
+func EncryptPKCS8PrivateKey(prv *gost3410.PrivateKey, password []byte, rand io.Reader) ([]byte, error) {
+	plain, err := MarshalPKCS8PrivateKey(prv)
+	if err != nil {
+		return nil, err
+	}
+	salt := make([]byte, pbkdf2SaltLen)
+	iv := make([]byte, cipherIVLen)
+	if _, err := io.ReadFull(rand, salt); err != nil {
+		return nil, err
+	}
+	if _, err := io.ReadFull(rand, iv); err != nil {
+		return nil, err
+	}
+	key, err := gost341194.PBKDF2(password, salt, pbkdf2Iter, cipherKeyLen, &gost341194.SboxIdGostR341194CryptoProParamSet)
+	if err != nil {
+		return nil, err
+	}
+	data := make([]byte, len(plain))
+	gost28147.NewCipher(key, &gost28147.SboxIdGost2814789CryptoProAParamSet).NewCFBEncrypter(iv).XORKeyStream(data, plain)
+
+	kdf, err := asn1.Marshal(pbkdf2Params{
+		Salt:           salt,
+		IterationCount: pbkdf2Iter,
+		PRF:            pkix.AlgorithmIdentifier{Algorithm: oidHMACGostR341194, Parameters: asn1.NullRawValue},
+	})
+	if err != nil {
+		return nil, err
+	}
+	enc, err := asn1.Marshal(gost28147Params{IV: iv, EncryptionParamSet: oidCipherParamSetA})
+	if err != nil {
+		return nil, err
+	}
+	params, err := asn1.Marshal(pbes2Params{
+		KeyDerivationFunc: pkix.AlgorithmIdentifier{Algorithm: oidPBKDF2, Parameters: asn1.RawValue{FullBytes: kdf}},
+		EncryptionScheme:  pkix.AlgorithmIdentifier{Algorithm: oidGost2814789, Parameters: asn1.RawValue{FullBytes: enc}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return asn1.Marshal(encryptedPrivateKeyInfo{
+		Algorithm:     pkix.AlgorithmIdentifier{Algorithm: oidPBES2, Parameters: asn1.RawValue{FullBytes: params}},
+		EncryptedData: data,
+	})
+}
+
+// DecryptPKCS8PrivateKey расшифровывает закрытый ключ из
+// EncryptedPrivateKeyInfo (DER), созданного EncryptPKCS8PrivateKey.
+// Ключи с числом итераций PBKDF2 больше 1<<20 отклоняются
+func DecryptPKCS8PrivateKey(der, password []byte) (*gost3410.PrivateKey, error) {
+	var info encryptedPrivateKeyInfo
+	if rest, err := asn1.Unmarshal(der, &info); err != nil || len(rest) != 0 {
+		return nil, errKeyFormat
+	}
+	if !info.Algorithm.Algorithm.Equal(oidPBES2) {
+		return nil, errEncryption
+	}
+	var params pbes2Params
+	if _, err := asn1.Unmarshal(info.Algorithm.Parameters.FullBytes, &params); err != nil {
+		return nil, errKeyFormat
+	}
+
+	if !params.KeyDerivationFunc.Algorithm.Equal(oidPBKDF2) {
+		return nil, errEncryption
+	}
+	var kdf pbkdf2Params
+	if _, err := asn1.Unmarshal(params.KeyDerivationFunc.Parameters.FullBytes, &kdf); err != nil {
+		return nil, errKeyFormat
+	}
+	if !kdf.PRF.Algorithm.Equal(oidHMACGostR341194) || (kdf.KeyLength != 0 && kdf.KeyLength != cipherKeyLen) {
+		return nil, errEncryption
+	}
+	// Число итераций берется из файла ключа: слишком большое значение
+	// заняло бы процессор на часы
+	if kdf.IterationCount < 1 || kdf.IterationCount > pbkdf2MaxIter {
+		return nil, errEncryption
+	}
+
+	if !params.EncryptionScheme.Algorithm.Equal(oidGost2814789) {
+		return nil, errEncryption
+	}
+	var enc gost28147Params
+	if _, err := asn1.Unmarshal(params.EncryptionScheme.Parameters.FullBytes, &enc); err != nil || len(enc.IV) != cipherIVLen {
+		return nil, errKeyFormat
+	}
+	var sbox *gost28147.Sbox
+	for _, s := range cipherSboxes {
+		if s.oid.Equal(enc.EncryptionParamSet) {
+			sbox = s.sbox
+		}
+	}
+	if sbox == nil {
+		return nil, errEncryption
+	}
+
+	key, err := gost341194.PBKDF2(password, kdf.Salt, kdf.IterationCount, cipherKeyLen, &gost341194.SboxIdGostR341194CryptoProParamSet)
+	if err != nil {
+		return nil, err
+	}
+	plain := make([]byte, len(info.EncryptedData))
+	gost28147.NewCipher(key, sbox).NewCFBDecrypter(enc.IV).XORKeyStream(plain, info.EncryptedData)
+	prv, err := ParsePKCS8PrivateKey(plain)
+	if err != nil {
+		return nil, errPassword
+	}
+	return prv, nil
+}
@@ -0,0 +1,153 @@
+package gost34102001
+
+import (
+	"bytes"         // Пакет для работы с байтовыми срезами
+	"crypto/rand"   // Пакет с криптографически стойким генератором случайных чисел
+	"encoding/asn1" // Пакет для кодирования структур ASN.1 DER
+	"testing"       // Пакет для написания тестов
+
+	"main/gost341194" // Хеш-функция ГОСТ Р 34.11-94
+)
+
+func TestPKCS8RoundTrip(t *testing.T) {
+	for _, c := range Curves() {
+		t.Run(c.Name, func(t *testing.T) {
+			prv, err := GenerateKey(&c, rand.Reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+			der, err := MarshalPKCS8PrivateKey(prv)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := ParsePKCS8PrivateKey(der)
+			if err != nil || got.Key.Cmp(prv.Key) != 0 {
+				t.Fatalf("PrivateKeyInfo: ключ не восстановлен (%v)", err)
+			}
+
+			if der, err = EncryptPKCS8PrivateKey(prv, []byte("password"), rand.Reader); err != nil {
+				t.Fatal(err)
+			}
+			got, err = DecryptPKCS8PrivateKey(der, []byte("password"))
+			if err != nil || got.Key.Cmp(prv.Key) != 0 {
+				t.Fatalf("EncryptedPrivateKeyInfo: ключ не восстановлен (%v)", err)
+			}
+			if _, err := DecryptPKCS8PrivateKey(der, []byte("wrong")); err != errPassword {
+				t.Errorf("неверный пароль: ошибка %v, ожидалась %v", err, errPassword)
+			}
+		})
+	}
+}
+
+func TestPKIXRoundTrip(t *testing.T) {
+	for _, c := range Curves() {
+		t.Run(c.Name, func(t *testing.T) {
+			prv, err := GenerateKey(&c, rand.Reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+			pub, err := prv.PublicKey()
+			if err != nil {
+				t.Fatal(err)
+			}
+			der, err := MarshalPKIXPublicKey(pub)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := ParsePKIXPublicKey(der)
+			if err != nil || !bytes.Equal(got.Raw(), pub.Raw()) {
+				t.Fatalf("ключ не восстановлен (%v)", err)
+			}
+		})
+	}
+}
+
+// withIterations возвращает копию EncryptedPrivateKeyInfo der с числом
+// итераций PBKDF2, замененным на iter
+func withIterations(t *testing.T, der []byte, iter int) []byte {
+	t.Helper()
+	var info encryptedPrivateKeyInfo
+	var params pbes2Params
+	var kdf pbkdf2Params
+	if _, err := asn1.Unmarshal(der, &info); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := asn1.Unmarshal(info.Algorithm.Parameters.FullBytes, &params); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := asn1.Unmarshal(params.KeyDerivationFunc.Parameters.FullBytes, &kdf); err != nil {
+		t.Fatal(err)
+	}
+	kdf.IterationCount = iter
+	b, err := asn1.Marshal(kdf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	params.KeyDerivationFunc.Parameters = asn1.RawValue{FullBytes: b}
+	if b, err = asn1.Marshal(params); err != nil {
+		t.Fatal(err)
+	}
+	info.Algorithm.Parameters = asn1.RawValue{FullBytes: b}
+	if der, err = asn1.Marshal(info); err != nil {
+		t.Fatal(err)
+	}
+	return der
+}
+
+// Число итераций из файла ключа ограничено, иначе ключ с 2^31 итерациями
+// надолго занял бы процессор
+func TestDecryptPKCS8Iterations(t *testing.T) {
+	prv, err := GenerateKey(&Curves()[0], rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := EncryptPKCS8PrivateKey(prv, []byte("password"), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, iter := range []int{0, -1, pbkdf2MaxIter + 1, 1<<31 - 1} {
+		if _, err := DecryptPKCS8PrivateKey(withIterations(t, der, iter), []byte("password")); err != errEncryption {
+			t.Errorf("%d итераций: ошибка %v, ожидалась %v", iter, err, errEncryption)
+		}
+	}
+
+	// Ключ с допустимым числом итераций расшифровывается до проверки пароля
+	if _, err := DecryptPKCS8PrivateKey(withIterations(t, der, 1), []byte("password")); err != errPassword {
+		t.Errorf("1 итерация: ошибка %v, ожидалась %v", err, errPassword)
+	}
+}
+
+// Ключи записываются с набором параметров хеш-функции CryptoPro, ключи
+// с другим набором отклоняются
+func TestKeyAlgorithmDigestParamSet(t *testing.T) {
+	c := Curves()[0]
+	want, err := gost341194.ParamSetByName("cryptopro")
+	if err != nil {
+		t.Fatal(err)
+	}
+	alg, err := keyAlgorithm(c.Curve())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var params publicKeyParams
+	if _, err := asn1.Unmarshal(alg.Parameters.FullBytes, &params); err != nil {
+		t.Fatal(err)
+	}
+	if !params.PublicKeyParamSet.Equal(c.OID) || !params.DigestParamSet.Equal(want.OID) {
+		t.Errorf("параметры ключа %v, %v; ожидались %v, %v", params.PublicKeyParamSet, params.DigestParamSet, c.OID, want.OID)
+	}
+
+	other, err := gost341194.ParamSetByName("test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	params.DigestParamSet = other.OID
+	b, err := asn1.Marshal(params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	alg.Parameters = asn1.RawValue{FullBytes: b}
+	if _, err := parseKeyAlgorithm(&alg); err != errKeyDigest {
+		t.Errorf("набор %s: ошибка %v, ожидалась %v", other.Name, err, errKeyDigest)
+	}
+}
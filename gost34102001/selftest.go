@@ -0,0 +1,79 @@
+package gost34102001
+
+import (
+	"bytes"        // Пакет для сравнения байтовых срезов
+	"encoding/hex" // Пакет для кодирования/декодирования шестнадцатеричных строк
+	"fmt"          // Пакет для форматирования сообщений об ошибках
+
+	"github.com/ftomza/gogost/gost3410" // Эллиптические кривые ГОСТ Р 34.10
+)
+
+// Пример из ГОСТ Р 34.10-2001 (RFC 5832, раздел 7.1) для набора параметров
+// test; все числа записаны старшим байтом вперед
+const (
+	rfc5832D = "7a929ade789bb9be10ed359dd39a72c11b60961f49397eee1d19ce9891ec3b28" // Закрытый ключ d
+	rfc5832X = "7f2b49e270db6d90d8595bec458b50c58585ba1d4e9b788f6689dbd8e56fd80b" // Открытый ключ, x
+	rfc5832Y = "26f1b489d6701dd185c8413a977b3cbbaf64d1c593d26627dffb101a87ff77da" // Открытый ключ, y
+	rfc5832E = "2dfbc1b372d89a1188c09c52e0eec61fce52032ab1022e8e67ece6672b043ee5" // Хеш-значение e
+	rfc5832K = "77105c9b20bcd3122823c8cf6fcc7b956de33814e95b7fe64fed924594dceab3" // Случайное число k
+	rfc5832R = "41aa28d2f1ab148280cd9ed56feda41974053554a42767b83ad043fd39dc0493" // Подпись, r
+	rfc5832S = "01456c64ba4642a1653c235a98a60249bcd6d3f746b631df928014f6c5bf9c40" // Подпись, s
+)
+
+// SelfTest проверяет во время выполнения формирование подписи на примере из
+// RFC 5832 и ее проверку, восстановление ключей из PKCS#8 и
+// SubjectPublicKeyInfo. Возвращает ошибку при первом несовпадении; полные
+// проверки - в тестах пакета
+func SelfTest() error {
+	prv, digest, k, err := rfc5832Example()
+	if err != nil {
+		return err
+	}
+	pub, err := prv.PublicKey()
+	if err != nil {
+		return err
+	}
+	want, _ := hex.DecodeString(rfc5832S + rfc5832R)
+	sig, err := SignDigest(prv, digest, bytes.NewReader(k))
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(sig, want) {
+		return fmt.Errorf("gost34102001: самопроверка подписи: получено %x, ожидалось %x", sig, want)
+	}
+	if err := VerifyDigest(pub, digest, sig); err != nil {
+		return fmt.Errorf("gost34102001: самопроверка проверки подписи: %v", err)
+	}
+
+	// Ключи должны восстанавливаться из PKCS#8 и SubjectPublicKeyInfo
+	der, err := EncryptPKCS8PrivateKey(prv, []byte("password"), bytes.NewReader(make([]byte, pbkdf2SaltLen+cipherIVLen)))
+	if err != nil {
+		return err
+	}
+	prv2, err := DecryptPKCS8PrivateKey(der, []byte("password"))
+	if err != nil || prv2.Key.Cmp(prv.Key) != 0 {
+		return fmt.Errorf("gost34102001: самопроверка PKCS#8: закрытый ключ не восстановлен (%v)", err)
+	}
+	if der, err = MarshalPKIXPublicKey(pub); err != nil {
+		return err
+	}
+	pub2, err := ParsePKIXPublicKey(der)
+	if err != nil || !bytes.Equal(pub2.Raw(), pub.Raw()) {
+		return fmt.Errorf("gost34102001: самопроверка SubjectPublicKeyInfo: открытый ключ не восстановлен (%v)", err)
+	}
+
+	return nil
+}
+
+// rfc5832Example возвращает закрытый ключ, хеш-значение (младшим байтом
+// вперед) и случайное число k из примера RFC 5832
+func rfc5832Example() (*gost3410.PrivateKey, []byte, []byte, error) {
+	d, _ := hex.DecodeString(rfc5832D)
+	e, _ := hex.DecodeString(rfc5832E)
+	k, _ := hex.DecodeString(rfc5832K)
+	prv, err := gost3410.NewPrivateKey(gost3410.CurveIdGostR34102001TestParamSet(), reversed(d))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return prv, reversed(e), k, nil
+}
@@ -0,0 +1,53 @@
+package gost34102001
+
+import (
+	"errors"   // Пакет для создания ошибок
+	"math/big" // Пакет для работы с большими числами
+
+	"github.com/ftomza/gogost/gost3410" // Эллиптические кривые ГОСТ Р 34.10
+)
+
+const (
+	UKMSize = 8  // Размер UKM (user keying material) в байтах
+	KEKSize = 32 // Размер общего ключа в байтах
+)
+
+var (
+	errUKMSize       = errors.New("gost34102001: неверный размер UKM")
+	errCurveMismatch = errors.New("gost34102001: ключи относятся к разным кривым")
+)
+
+// KEK вычисляет общий ключ VKO GOST R 34.10-2001 (RFC 4357, раздел 5.2) из
+// своего закрытого ключа prv, открытого ключа другой стороны pub и UKM:
+// KEK = H(K), где K = (cofactor * UKM * prv) * pub, а H - ГОСТ Р 34.11-94
+// с параметрами CryptoPro. UKM рассматривается как число, младший байт
+// которого идет первым; нулевое UKM заменяется единицей, как в RFC 7836
+func KEK(prv *gost3410.PrivateKey, pub *gost3410.PublicKey, ukm []byte) ([]byte, error) {
+	if len(ukm) != UKMSize {
+		return nil, errUKMSize
+	}
+	if prv.C.Name != pub.C.Name {
+		return nil, errCurveMismatch
+	}
+	u := new(big.Int).SetBytes(reversed(ukm))
+	if u.Sign() == 0 {
+		u.SetInt64(1)
+	}
+	u.Mul(u, prv.C.Co)
+
+	x, y, err := prv.C.Exp(prv.Key, pub.X, pub.Y)
+	if err != nil {
+		return nil, err
+	}
+	if u.Cmp(big.NewInt(1)) != 0 {
+		if x, y, err = prv.C.Exp(u, x, y); err != nil {
+			return nil, err
+		}
+	}
+
+	// Хешируются координаты x и y точки K младшим байтом вперед
+	k := gost3410.PublicKey{C: prv.C, X: x, Y: y}
+	h := NewHash()
+	h.Write(k.Raw())
+	return h.Sum(nil), nil
+}
@@ -0,0 +1,90 @@
+package gost34102001
+
+import (
+	"crypto/rand"  // Пакет с криптографически стойким генератором случайных чисел
+	"encoding/hex" // Пакет для кодирования/декодирования шестнадцатеричных строк
+	"testing"      // Пакет для написания тестов
+
+	"github.com/ftomza/gogost/gost3410" // Эллиптические кривые ГОСТ Р 34.10
+)
+
+// Известный ответ VKO GOST R 34.10-2001 для набора параметров test из набора
+// тестов GoGOST; ключи и UKM записаны младшим байтом вперед
+const (
+	vkoPrv1 = "1df129e43dab345b68f6a852f4162dc69f36b2f84717d08755cc5c44150bf928" // Закрытый ключ первой стороны
+	vkoPrv2 = "5b9356c6474f913f1e83885ea0edd5df1a43fd9d799d219093241157ac9ed473" // Закрытый ключ второй стороны
+	vkoUKM  = "5172be25f852a233"                                                 // UKM
+	vkoKEK  = "ee4618a0dbb10cb31777b4b86a53d9e7ef6cb3e400101410f0c0f2af46c494a6" // Общий ключ
+)
+
+// vkoKeys возвращает ключевые пары обеих сторон из известного ответа VKO
+func vkoKeys(t *testing.T) (prv1, prv2 *gost3410.PrivateKey, pub1, pub2 *gost3410.PublicKey) {
+	t.Helper()
+	curve := gost3410.CurveIdGostR34102001TestParamSet()
+	raw1, _ := hex.DecodeString(vkoPrv1)
+	raw2, _ := hex.DecodeString(vkoPrv2)
+	var err error
+	if prv1, err = gost3410.NewPrivateKey(curve, raw1); err != nil {
+		t.Fatal(err)
+	}
+	if prv2, err = gost3410.NewPrivateKey(curve, raw2); err != nil {
+		t.Fatal(err)
+	}
+	if pub1, err = prv1.PublicKey(); err != nil {
+		t.Fatal(err)
+	}
+	if pub2, err = prv2.PublicKey(); err != nil {
+		t.Fatal(err)
+	}
+	return prv1, prv2, pub1, pub2
+}
+
+// Обе стороны получают известный общий ключ
+func TestKEK(t *testing.T) {
+	prv1, prv2, pub1, pub2 := vkoKeys(t)
+	ukm, _ := hex.DecodeString(vkoUKM)
+	for i, side := range []struct {
+		prv *gost3410.PrivateKey
+		pub *gost3410.PublicKey
+	}{{prv1, pub2}, {prv2, pub1}} {
+		kek, err := KEK(side.prv, side.pub, ukm)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := hex.EncodeToString(kek); got != vkoKEK {
+			t.Errorf("сторона %d: получено %s, ожидалось %s", i+1, got, vkoKEK)
+		}
+	}
+}
+
+// Нулевое UKM заменяется единицей
+func TestKEKZeroUKM(t *testing.T) {
+	prv1, _, _, pub2 := vkoKeys(t)
+	zero, err := KEK(prv1, pub2, make([]byte, UKMSize))
+	if err != nil {
+		t.Fatal(err)
+	}
+	one, _ := KEK(prv1, pub2, []byte{1, 0, 0, 0, 0, 0, 0, 0})
+	if hex.EncodeToString(zero) != hex.EncodeToString(one) {
+		t.Errorf("UKM = 0: %x, UKM = 1: %x", zero, one)
+	}
+}
+
+func TestKEKErrors(t *testing.T) {
+	prv1, _, _, pub2 := vkoKeys(t)
+	if _, err := KEK(prv1, pub2, make([]byte, UKMSize-1)); err != errUKMSize {
+		t.Errorf("короткое UKM: ошибка %v, ожидалась %v", err, errUKMSize)
+	}
+	c, _ := CurveByName("cryptopro-a")
+	other, err := GenerateKey(c, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherPub, err := other.PublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := KEK(prv1, otherPub, make([]byte, UKMSize)); err != errCurveMismatch {
+		t.Errorf("разные кривые: ошибка %v, ожидалась %v", err, errCurveMismatch)
+	}
+}
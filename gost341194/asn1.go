@@ -0,0 +1,106 @@
+package gost341194
+
+import (
+	"crypto/x509/pkix" // Пакет со структурой AlgorithmIdentifier
+	"encoding/asn1"    // Пакет для кодирования структур ASN.1 DER
+	"errors"           // Пакет для создания ошибок
+)
+
+// OIDGostR341194 - идентификатор алгоритма id-GostR3411-94 (RFC 4357)
+var OIDGostR341194 = asn1.ObjectIdentifier{1, 2, 643, 2, 2, 9}
+
+var (
+	errAlgorithm  = errors.New("gost341194: алгоритм не является ГОСТ Р 34.11-94")
+	errASN1       = errors.New("gost341194: неверная структура ASN.1")
+	errDigestSize = errors.New("gost341194: неверный размер хеш-значения")
+	errNoOID      = errors.New("gost341194: у набора параметров нет OID хеш-функции")
+)
+
+// DigestInfo - структура DigestInfo (PKCS#1, RFC 8017) с хеш-значением
+// ГОСТ Р 34.11-94 в порядке байтов результата Sum
+type DigestInfo struct {
+	Algorithm pkix.AlgorithmIdentifier
+	Digest    []byte
+}
+
+// AlgorithmIdentifier возвращает AlgorithmIdentifier хеш-функции с набором
+// параметров p: id-GostR3411-94 с OID набора в качестве параметров. Для
+// набора без OID (tc26-z) возвращает ошибку
+func (p *ParamSet) AlgorithmIdentifier() (pkix.AlgorithmIdentifier, error) {
+	if len(p.OID) == 0 {
+		return pkix.AlgorithmIdentifier{}, errNoOID
+	}
+	params, err := asn1.Marshal(p.OID)
+	if err != nil {
+		return pkix.AlgorithmIdentifier{}, err
+	}
+	return pkix.AlgorithmIdentifier{Algorithm: OIDGostR341194, Parameters: asn1.RawValue{FullBytes: params}}, nil
+}
+
+// ParamSetByAlgorithm возвращает набор параметров (и S-блоки) хеш-функции,
+// указанный в AlgorithmIdentifier. При отсутствии параметров или NULL
+// подразумевается набор CryptoPro (RFC 4490)
+func ParamSetByAlgorithm(alg *pkix.AlgorithmIdentifier) (*ParamSet, error) {
+	if !alg.Algorithm.Equal(OIDGostR341194) {
+		return nil, errAlgorithm
+	}
+	params := alg.Parameters.FullBytes
+	if len(params) == 0 || alg.Parameters.Tag == asn1.TagNull {
+		return ParamSetByName("cryptopro")
+	}
+	var oid asn1.ObjectIdentifier
+	if rest, err := asn1.Unmarshal(params, &oid); err != nil || len(rest) != 0 {
+		return nil, errASN1
+	}
+	return ParamSetByOID(oid.String())
+}
+
+// MarshalAlgorithmIdentifier кодирует AlgorithmIdentifier хеш-функции
+// с набором параметров p в DER
+func MarshalAlgorithmIdentifier(p *ParamSet) ([]byte, error) {
+	alg, err := p.AlgorithmIdentifier()
+	if err != nil {
+		return nil, err
+	}
+	return asn1.Marshal(alg)
+}
+
+// ParseAlgorithmIdentifier разбирает AlgorithmIdentifier в DER и возвращает
+// набор параметров хеш-функции
+func ParseAlgorithmIdentifier(der []byte) (*ParamSet, error) {
+	var alg pkix.AlgorithmIdentifier
+	if rest, err := asn1.Unmarshal(der, &alg); err != nil || len(rest) != 0 {
+		return nil, errASN1
+	}
+	return ParamSetByAlgorithm(&alg)
+}
+
+// MarshalDigestInfo кодирует DigestInfo с хеш-значением digest, вычисленным
+// с набором параметров p, в DER
+func MarshalDigestInfo(p *ParamSet, digest []byte) ([]byte, error) {
+	if len(digest) != Size {
+		return nil, errDigestSize
+	}
+	alg, err := p.AlgorithmIdentifier()
+	if err != nil {
+		return nil, err
+	}
+	return asn1.Marshal(DigestInfo{Algorithm: alg, Digest: digest})
+}
+
+// ParseDigestInfo разбирает DigestInfo в DER и возвращает набор параметров,
+// которым вычислено хеш-значение, и само хеш-значение
+func ParseDigestInfo(der []byte) (*ParamSet, []byte, error) {
+	var info DigestInfo
+	if rest, err := asn1.Unmarshal(der, &info); err != nil || len(rest) != 0 {
+		return nil, nil, errASN1
+	}
+	if len(info.Digest) != Size {
+		return nil, nil, errDigestSize
+	}
+	p, err := ParamSetByAlgorithm(&info.Algorithm)
+	if err != nil {
+		return nil, nil, err
+	}
+	return p, info.Digest, nil
+}
@@ -0,0 +1,150 @@
+package gost341194
+
+import (
+	"bytes"        // Пакет для сравнения байтовых срезов
+	"encoding/hex" // Пакет для кодирования/декодирования шестнадцатеричных строк
+	"testing"      // Пакет для написания тестов
+)
+
+// Ожидаемые DER AlgorithmIdentifier: id-GostR3411-94 (06 06 2a8503020209)
+// с OID набора параметров
+var algorithmDER = []struct {
+	name string
+	der  string
+}{
+	{"test", "301106062a850302020906072a850302021e00"},
+	{"cryptopro", "301106062a850302020906072a850302021e01"},
+}
+
+// mustHex декодирует шестнадцатеричную строку
+func mustHex(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestAlgorithmIdentifier(t *testing.T) {
+	for _, v := range algorithmDER {
+		t.Run(v.name, func(t *testing.T) {
+			p, err := ParamSetByName(v.name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			der, err := MarshalAlgorithmIdentifier(p)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := hex.EncodeToString(der); got != v.der {
+				t.Errorf("получено %s, ожидалось %s", got, v.der)
+			}
+			q, err := ParseAlgorithmIdentifier(der)
+			if err != nil || q.Name != p.Name {
+				t.Errorf("разбор: получено %v (%v), ожидалось %s", q, err, p.Name)
+			}
+		})
+	}
+}
+
+func TestDigestInfo(t *testing.T) {
+	digest := make([]byte, Size)
+	for i := range digest {
+		digest[i] = byte(i)
+	}
+	for _, v := range algorithmDER {
+		t.Run(v.name, func(t *testing.T) {
+			p, err := ParamSetByName(v.name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			der, err := MarshalDigestInfo(p, digest)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := "3035" + v.der + "0420" + hex.EncodeToString(digest)
+			if got := hex.EncodeToString(der); got != want {
+				t.Errorf("получено %s, ожидалось %s", got, want)
+			}
+			q, d, err := ParseDigestInfo(der)
+			if err != nil || q.Name != p.Name || !bytes.Equal(d, digest) {
+				t.Errorf("разбор: получено %v, %x (%v)", q, d, err)
+			}
+		})
+	}
+}
+
+// Без параметров и с параметром NULL подразумевается набор CryptoPro
+func TestAlgorithmIdentifierDefault(t *testing.T) {
+	for _, der := range []string{
+		"300806062a8503020209",     // Параметры отсутствуют
+		"300a06062a85030202090500", // NULL
+	} {
+		p, err := ParseAlgorithmIdentifier(mustHex(t, der))
+		if err != nil || p.Name != "cryptopro" {
+			t.Errorf("%s: получено %v (%v), ожидался cryptopro", der, p, err)
+		}
+	}
+}
+
+func TestAlgorithmIdentifierErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		der  string
+		err  error // nil - ошибка поиска набора параметров по OID
+	}{
+		{"неизвестный набор", "301106062a850302020906072a850302021e05", nil},
+		{"OID шифра в параметрах", "301306062a850302020906092a8503070102050101", nil},
+		{"другой алгоритм", "301106062a850302020a06072a850302021e01", errAlgorithm},
+		{"параметры не OID", "300b06062a8503020209020101", errASN1},
+		{"лишние данные", "301106062a850302020906072a850302021e0100", errASN1},
+		{"не SEQUENCE", "06062a8503020209", errASN1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseAlgorithmIdentifier(mustHex(t, tt.der))
+			if err == nil || err != tt.err && (tt.err != nil || err == errASN1 || err == errAlgorithm) {
+				t.Errorf("ошибка %v, ожидалась %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestDigestInfoErrors(t *testing.T) {
+	p, err := ParamSetByName("cryptopro")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, n := range []int{0, Size - 1, Size + 1} {
+		if _, err := MarshalDigestInfo(p, make([]byte, n)); err != errDigestSize {
+			t.Errorf("MarshalDigestInfo, %d байт: ошибка %v, ожидалась %v", n, err, errDigestSize)
+		}
+	}
+
+	// Хеш-значение длиной 31 байт
+	short := "3034" + algorithmDER[1].der + "041f" + hex.EncodeToString(make([]byte, Size-1))
+	if _, _, err := ParseDigestInfo(mustHex(t, short)); err != errDigestSize {
+		t.Errorf("ParseDigestInfo, 31 байт: ошибка %v, ожидалась %v", err, errDigestSize)
+	}
+	valid := "3035" + algorithmDER[1].der + "0420" + hex.EncodeToString(make([]byte, Size))
+	if _, _, err := ParseDigestInfo(mustHex(t, valid+"00")); err != errASN1 {
+		t.Errorf("ParseDigestInfo, лишние данные: ошибка %v, ожидалась %v", err, errASN1)
+	}
+	other := "3035" + "301106062a850302020a06072a850302021e01" + "0420" + hex.EncodeToString(make([]byte, Size))
+	if _, _, err := ParseDigestInfo(mustHex(t, other)); err != errAlgorithm {
+		t.Errorf("ParseDigestInfo, другой алгоритм: ошибка %v, ожидалась %v", err, errAlgorithm)
+	}
+
+	// У tc26-z нет OID хеш-функции
+	z, err := ParamSetByName("tc26-z")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := MarshalAlgorithmIdentifier(z); err != errNoOID {
+		t.Errorf("MarshalAlgorithmIdentifier(tc26-z): ошибка %v, ожидалась %v", err, errNoOID)
+	}
+	if _, err := MarshalDigestInfo(z, make([]byte, Size)); err != errNoOID {
+		t.Errorf("MarshalDigestInfo(tc26-z): ошибка %v, ожидалась %v", err, errNoOID)
+	}
+}
@@ -0,0 +1,59 @@
+package compress
+
+import (
+	"encoding/binary" // Пакет для работы с бинарными данными
+	"math/bits"       // Пакет для битовых операций
+)
+
+// sboxTable содержит S-блоки, объединенные попарно в четыре таблицы замены
+// байта на 32-битное слово, уже сдвинутое циклически на 11 бит
+type sboxTable [4][256]uint32
+
+// newSboxTable строит таблицы замены для указанных S-блоков
+func newSboxTable(sbox *Sbox) *sboxTable {
+	t := new(sboxTable)
+	for i := range t {
+		for b := range t[i] {
+			v := uint32(sbox[2*i][b&0x0f]) | uint32(sbox[2*i+1][b>>4])<<4
+			t[i][b] = bits.RotateLeft32(v<<(8*i), 11)
+		}
+	}
+	return t
+}
+
+// cipher реализует зашифрование одного блока по ГОСТ 28147-89 в режиме
+// простой замены. Ключ устанавливается повторно без выделения памяти
+type cipher struct {
+	t      *sboxTable // Таблицы замены
+	x      [8]uint32  // Подключи K0..K7
+	rounds int        // Число раундов (32 по стандарту)
+}
+
+// setKey устанавливает 256-битный ключ шифрования
+func (c *cipher) setKey(key *[BlockSize]byte) {
+	for i := range c.x {
+		c.x[i] = binary.LittleEndian.Uint32(key[4*i:])
+	}
+}
+
+// f - функция раунда: замена по S-блокам и циклический сдвиг на 11 бит влево
+func (c *cipher) f(n uint32) uint32 {
+	return c.t[0][n&0xff] ^ c.t[1][n>>8&0xff] ^ c.t[2][n>>16&0xff] ^ c.t[3][n>>24]
+}
+
+// encryptReversed зашифровывает 8-байтовый блок src в dst. Хеш-функция хранит
+// блоки в обратном порядке байтов, поэтому вход и выход инвертируются
+func (c *cipher) encryptReversed(dst, src []byte) {
+	n1 := binary.BigEndian.Uint32(src[4:8])
+	n2 := binary.BigEndian.Uint32(src[0:4])
+	// Первые 24 раунда используют подключи K0..K7 в прямом порядке
+	for r := 0; r < c.rounds && r < 24; r++ {
+		n1, n2 = c.f(n1+c.x[r%8])^n2, n1
+	}
+	// Последние 8 раундов - подключи K7..K0 в обратном порядке
+	for r := 24; r < c.rounds; r++ {
+		n1, n2 = c.f(n1+c.x[31-r])^n2, n1
+	}
+	binary.BigEndian.PutUint32(dst[0:4], n1)
+	binary.BigEndian.PutUint32(dst[4:8], n2)
+}
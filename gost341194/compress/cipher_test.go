@@ -0,0 +1,54 @@
+package compress
+
+import (
+	"bytes"     // Пакет для сравнения байтовых срезов
+	"math/rand" // Пакет с детерминированным генератором случайных чисел
+	"testing"   // Пакет для написания тестов
+
+	"github.com/ftomza/gogost/gost28147" // Эталонная реализация ГОСТ 28147-89
+)
+
+// Наборы S-блоков, которые используют пакеты gost341194 и gost34102001
+var cipherSboxes = []struct {
+	name string
+	sbox *gost28147.Sbox
+}{
+	{"test", &gost28147.SboxIdGostR341194TestParamSet},
+	{"cryptopro", &gost28147.SboxIdGostR341194CryptoProParamSet},
+	{"tc26-z", &gost28147.SboxIdtc26gost28147paramZ},
+}
+
+// reverse8 возвращает 8-байтовый блок в обратном порядке байтов
+func reverse8(b []byte) []byte {
+	r := make([]byte, len(b))
+	for i := range b {
+		r[len(b)-1-i] = b[i]
+	}
+	return r
+}
+
+// Табличный шифр должен совпадать с gost28147.Cipher: encryptReversed
+// принимает и возвращает блоки в обратном порядке байтов
+func TestCipherGogost(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, s := range cipherSboxes {
+		t.Run(s.name, func(t *testing.T) {
+			sbox := Sbox(*s.sbox)
+			c := cipher{t: newSboxTable(&sbox), rounds: 32}
+			var key [BlockSize]byte
+			src := make([]byte, 8)
+			got := make([]byte, 8)
+			want := make([]byte, 8)
+			for i := 0; i < 2000; i++ {
+				rnd.Read(key[:])
+				rnd.Read(src)
+				c.setKey(&key)
+				c.encryptReversed(got, src)
+				gost28147.NewCipher(key[:], s.sbox).Encrypt(want, reverse8(src))
+				if want = reverse8(want); !bytes.Equal(got, want) {
+					t.Fatalf("ключ %x, блок %x: получено %x, ожидалось %x", key, src, got, want)
+				}
+			}
+		})
+	}
+}
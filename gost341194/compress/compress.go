@@ -0,0 +1,208 @@
+// Пакет compress содержит функцию сжатия ГОСТ Р 34.11-94 и ее составные
+// преобразования A, P и psi для построения экспериментальных режимов
+// хеширования. Все 256-битные значения записаны как в стандарте: старший
+// байт первый (пакет gost341194 инвертирует порядок байтов блоков сообщения
+// перед сжатием)
+package compress
+
+const BlockSize = 32 // Размер блока и значения хеша в байтах (256 бит)
+
+// Sbox представляет восемь узлов замены (S-блоков) ГОСТ 28147-89
+// по 16 четырехбитовых элементов в каждом
+type Sbox [8][16]uint8
+
+var (
+	// Константы для преобразований в функции хеширования
+	c2 [BlockSize]byte = [BlockSize]byte{
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	}
+	// Константа c3 используется в алгоритме для нелинейных преобразований
+	c3 [BlockSize]byte = [BlockSize]byte{
+		0xff, 0x00, 0xff, 0xff, 0x00, 0x00, 0x00, 0xff,
+		0xff, 0x00, 0x00, 0xff, 0x00, 0xff, 0xff, 0x00,
+		0x00, 0xff, 0x00, 0xff, 0x00, 0xff, 0x00, 0xff,
+		0xff, 0x00, 0xff, 0x00, 0xff, 0x00, 0xff, 0x00,
+	}
+	// Константа c4 используется в алгоритме для нелинейных преобразований
+	c4 [BlockSize]byte = [BlockSize]byte{
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	}
+)
+
+// A - преобразование A из ГОСТ Р 34.11-94
+// Выполняет XOR и циклический сдвиг блоков на месте
+func A(x *[BlockSize]byte) {
+	var t [8]byte
+	// XOR последних двух блоков по 8 байт
+	for i := range t {
+		t[i] = x[16+i] ^ x[24+i]
+	}
+	// Сдвигаем первые 24 байта и помещаем результат XOR в начало
+	copy(x[8:], x[0:24])
+	copy(x[:8], t[:])
+}
+
+// P - перестановка P из ГОСТ Р 34.11-94
+// Переставляет байты src в dst: dst[4*i+j] = src[8*j+i]
+func P(dst, src *[BlockSize]byte) {
+	for i := 0; i < 8; i++ {
+		dst[4*i+0] = src[i]
+		dst[4*i+1] = src[8+i]
+		dst[4*i+2] = src[16+i]
+		dst[4*i+3] = src[24+i]
+	}
+}
+
+// Psi - преобразование psi из ГОСТ Р 34.11-94
+// Реализует линейное преобразование блока на месте
+func Psi(x *[BlockSize]byte) {
+	// Вычисляем первые два байта с помощью XOR нескольких байтов из входного блока
+	b0 := x[32-2] ^ x[32-4] ^ x[32-6] ^ x[32-8] ^ x[0] ^ x[32-26]
+	b1 := x[32-1] ^ x[32-3] ^ x[32-5] ^ x[32-7] ^ x[32-31] ^ x[32-25]
+	// Сдвигаем остальные байты на 2 позиции
+	copy(x[2:32], x[0:30])
+	x[0], x[1] = b0, b1
+}
+
+// blockReverse инвертирует порядок байтов в блоке
+func blockReverse(dst, src []byte) {
+	for i, j := 0, BlockSize-1; i < j; i, j = i+1, j-1 {
+		dst[i], dst[j] = src[j], src[i]
+	}
+}
+
+// blockXor выполняет побитовый XOR двух блоков
+func blockXor(dst, a, b *[BlockSize]byte) {
+	for i := 0; i < BlockSize; i++ {
+		dst[i] = a[i] ^ b[i]
+	}
+}
+
+// Keys вырабатывает ключи шифрования K1..K4 для текущего значения хеша h
+// и блока сообщения m
+func Keys(h, m *[BlockSize]byte) [4][BlockSize]byte {
+	return genKeys(h, m, &standard.KeyConsts)
+}
+
+// genKeys вырабатывает ключи шифрования с константами consts вместо C2, C3, C4
+func genKeys(h, m *[BlockSize]byte, consts *[3][BlockSize]byte) [4][BlockSize]byte {
+	var keys [4][BlockSize]byte
+	var w [BlockSize]byte
+	u, v := *h, *m
+	for i := range keys {
+		// Преобразуем u и v для очередного ключа
+		if i > 0 {
+			A(&u)
+			blockXor(&u, &u, &consts[i-1])
+			A(&v)
+			A(&v)
+		}
+		// K = P(u xor v)
+		blockXor(&w, &u, &v)
+		P(&keys[i], &w)
+	}
+	return keys
+}
+
+// Compressor вычисляет функцию сжатия с заранее построенными таблицами
+// замены и не выделяет память при вызове Compress
+type Compressor struct {
+	cph    cipher // Шифр ГОСТ 28147-89 с переиспользуемым ключом
+	p      Params // Число раундов и константы
+	tracer Tracer // Получатель событий трассировки (nil - трассировка отключена)
+}
+
+// NewCompressor создает функцию сжатия с указанными S-блоками
+func NewCompressor(sbox *Sbox) *Compressor {
+	return newCompressor(sbox, &standard)
+}
+
+// NewCompressorParams создает функцию сжатия с нестандартными параметрами.
+// Результат не является функцией ГОСТ Р 34.11-94, если p отличается от
+// StandardParams
+func NewCompressorParams(sbox *Sbox, p *Params) (*Compressor, error) {
+	if err := p.validate(); err != nil {
+		return nil, err
+	}
+	return newCompressor(sbox, p), nil
+}
+
+// newCompressor создает функцию сжатия без проверки параметров
+func newCompressor(sbox *Sbox, p *Params) *Compressor {
+	return &Compressor{cph: cipher{t: newSboxTable(sbox), rounds: p.CipherRounds}, p: *p}
+}
+
+// SetTracer устанавливает получателя событий EventKey, EventEncrypt и
+// EventPsi; nil отключает трассировку
+func (c *Compressor) SetTracer(t Tracer) {
+	c.tracer = t
+}
+
+// Compress выполняет один шаг хеш-функции ГОСТ Р 34.11-94
+// h - текущий хеш, заменяется новым значением; m - обрабатываемый блок данных
+func (c *Compressor) Compress(h, m *[BlockSize]byte) {
+	var out [BlockSize]byte
+	hin := *h
+
+	// Вырабатываем ключи шифрования
+	keys := genKeys(&hin, m, &c.p.KeyConsts)
+	if c.tracer != nil {
+		for i := range keys {
+			c.traceKey(i+1, &keys[i])
+		}
+	}
+
+	for i := range keys {
+		// Ключ используется шифром в обратном порядке байтов
+		blockReverse(keys[i][:], keys[i][:])
+		c.cph.setKey(&keys[i])
+
+		// Шифруем i-й блок текущего хеша (начиная со старших байтов)
+		// алгоритмом ГОСТ 28147-89, порядок байтов блока инвертирован
+		lo := BlockSize - 8*(i+1)
+		c.cph.encryptReversed(out[lo:lo+8], hin[lo:lo+8])
+		if c.tracer != nil {
+			c.traceEncrypt(i+1, hin[lo:lo+8], out[lo:lo+8])
+		}
+	}
+
+	// Применяем Leading раундов преобразования psi (12 по стандарту)
+	round := 1
+	for i := 0; i < c.p.Leading; i++ {
+		c.psi(&out, &round)
+	}
+	// Применяем XOR с входным блоком данных
+	blockXor(&out, &out, m)
+	// Middle раундов преобразования psi (один по стандарту)
+	for i := 0; i < c.p.Middle; i++ {
+		c.psi(&out, &round)
+	}
+	// Применяем XOR с текущим значением хеша
+	blockXor(&out, &out, &hin)
+	// Применяем еще Trailing раундов преобразования psi (61 по стандарту)
+	for i := 0; i < c.p.Trailing; i++ {
+		c.psi(&out, &round)
+	}
+	*h = out
+}
+
+// psi выполняет очередной раунд преобразования psi и увеличивает его номер
+func (c *Compressor) psi(x *[BlockSize]byte, round *int) {
+	Psi(x)
+	if c.tracer != nil {
+		c.tracePsi(*round, x)
+	}
+	*round++
+}
+
+// Compress выполняет один шаг хеш-функции с указанными S-блоками.
+// Для многократных вызовов выгоднее один раз создать Compressor
+func Compress(sbox *Sbox, h, m *[BlockSize]byte) {
+	NewCompressor(sbox).Compress(h, m)
+}
@@ -0,0 +1,45 @@
+package compress
+
+import (
+	"errors" // Пакет для создания ошибок
+)
+
+// Params задает число раундов и константы функции сжатия. Значения,
+// отличные от StandardParams, предназначены только для исследований
+type Params struct {
+	Leading      int                // Раунды psi до XOR с блоком сообщения (12)
+	Middle       int                // Раунды psi между XOR с блоком и с хешем (1)
+	Trailing     int                // Раунды psi после XOR с хешем (61)
+	KeyConsts    [3][BlockSize]byte // Константы C2, C3, C4 выработки ключей K2..K4
+	CipherRounds int                // Число раундов ГОСТ 28147-89 (32)
+}
+
+// Параметры функции сжатия по ГОСТ Р 34.11-94
+var standard = Params{
+	Leading:      12,
+	Middle:       1,
+	Trailing:     61,
+	KeyConsts:    [3][BlockSize]byte{c2, c3, c4},
+	CipherRounds: 32,
+}
+
+var (
+	errRounds       = errors.New("compress: число раундов psi не может быть отрицательным")
+	errCipherRounds = errors.New("compress: число раундов ГОСТ 28147-89 должно быть от 0 до 32")
+)
+
+// StandardParams возвращает параметры функции сжатия по ГОСТ Р 34.11-94
+func StandardParams() Params {
+	return standard
+}
+
+// validate проверяет допустимость параметров
+func (p *Params) validate() error {
+	if p.Leading < 0 || p.Middle < 0 || p.Trailing < 0 {
+		return errRounds
+	}
+	if p.CipherRounds < 0 || p.CipherRounds > 32 {
+		return errCipherRounds
+	}
+	return nil
+}
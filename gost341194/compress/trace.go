@@ -0,0 +1,84 @@
+package compress
+
+// EventKind определяет вид события трассировки хеш-функции
+type EventKind int
+
+const (
+	EventBlock    EventKind = iota // Поглощен блок сообщения
+	EventLength                    // Поглощен блок с длиной сообщения в битах
+	EventChecksum                  // Поглощен блок с контрольной суммой
+	EventKey                       // Выработан ключ шифрования K1..K4
+	EventEncrypt                   // Зашифрован блок текущего хеша по ГОСТ 28147-89
+	EventPsi                       // Выполнен раунд преобразования psi (fChi)
+)
+
+// String возвращает название вида события
+func (k EventKind) String() string {
+	switch k {
+	case EventBlock:
+		return "block"
+	case EventLength:
+		return "length"
+	case EventChecksum:
+		return "checksum"
+	case EventKey:
+		return "key"
+	case EventEncrypt:
+		return "encrypt"
+	case EventPsi:
+		return "psi"
+	}
+	return "unknown"
+}
+
+// Event описывает промежуточное значение функции сжатия. Все 256-битные
+// значения записаны как в стандарте: старший байт первый
+type Event struct {
+	Kind EventKind
+	// Номер ключа и зашифрования (1..4) или раунда psi (1..74)
+	Index int
+	// Поглощенный блок M (события блоков)
+	Block [BlockSize]byte
+	// Значение хеша H после поглощения блока (события блоков)
+	Hash [BlockSize]byte
+	// Контрольная сумма после поглощения блока (события блоков)
+	Checksum [BlockSize]byte
+	// Ключ K = P(U xor V) (EventKey)
+	Key [BlockSize]byte
+	// Зашифровываемая часть хеша h_i и результат s_i (EventEncrypt)
+	In, Out [8]byte
+	// Значение после раунда psi (EventPsi)
+	State [BlockSize]byte
+}
+
+// Tracer получает события трассировки хеш-функции. Событие действительно
+// только во время вызова Trace
+type Tracer interface {
+	Trace(e *Event)
+}
+
+// TracerFunc позволяет использовать обычную функцию в качестве Tracer
+type TracerFunc func(e *Event)
+
+// Trace вызывает f(e)
+func (f TracerFunc) Trace(e *Event) {
+	f(e)
+}
+
+// traceKey сообщает о выработке i-го ключа шифрования
+func (c *Compressor) traceKey(i int, k *[BlockSize]byte) {
+	c.tracer.Trace(&Event{Kind: EventKey, Index: i, Key: *k})
+}
+
+// traceEncrypt сообщает о зашифровании i-й части хеша
+func (c *Compressor) traceEncrypt(i int, in, out []byte) {
+	e := &Event{Kind: EventEncrypt, Index: i}
+	copy(e.In[:], in)
+	copy(e.Out[:], out)
+	c.tracer.Trace(e)
+}
+
+// tracePsi сообщает о выполнении раунда psi с номером round
+func (c *Compressor) tracePsi(round int, s *[BlockSize]byte) {
+	c.tracer.Trace(&Event{Kind: EventPsi, Index: round, State: *s})
+}
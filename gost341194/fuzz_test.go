@@ -0,0 +1,52 @@
+package gost341194
+
+import (
+	"bytes"   // Пакет для сравнения байтовых срезов
+	"testing" // Пакет для написания тестов
+
+	"github.com/ftomza/gogost/gost28147"               // S-блоки эталонной реализации
+	gogost341194 "github.com/ftomza/gogost/gost341194" // Эталонная реализация ГОСТ Р 34.11-94
+)
+
+// FuzzDiffGogost сравнивает хеш-значения с gogost для тестового набора
+// параметров и набора CryptoPro. Сообщение msg подается в Write порциями,
+// длины которых задают байты cuts
+func FuzzDiffGogost(f *testing.F) {
+	for _, n := range []int{31, 32, 33, 63, 64, 65} {
+		msg := make([]byte, n)
+		for i := range msg {
+			msg[i] = byte(i*7 + n)
+		}
+		f.Add(msg, []byte{byte(n / 2), 1, BlockSize})
+	}
+
+	sboxes := []struct {
+		own *Sbox
+		ref *gost28147.Sbox
+	}{
+		{&SboxIdGostR341194TestParamSet, &gost28147.SboxIdGostR341194TestParamSet},
+		{&SboxIdGostR341194CryptoProParamSet, &gost28147.SboxIdGostR341194CryptoProParamSet},
+	}
+
+	f.Fuzz(func(t *testing.T, msg, cuts []byte) {
+		for _, s := range sboxes {
+			ref := gogost341194.New(s.ref)
+			ref.Write(msg)
+			want := ref.Sum(nil)
+
+			h := New(s.own)
+			data := msg
+			for i := 0; len(data) > 0; i++ {
+				n := len(data)
+				if i < len(cuts) {
+					n = min(int(cuts[i])%(2*BlockSize+1), n)
+				}
+				h.Write(data[:n])
+				data = data[n:]
+			}
+			if got := h.Sum(nil); !bytes.Equal(got, want) {
+				t.Fatalf("сообщение %x, порции %v: получено %x, gogost %x", msg, cuts, got, want)
+			}
+		}
+	})
+}
@@ -0,0 +1,273 @@
+package gost341194
+
+import (
+	"bytes"        // Пакет для сравнения байтовых срезов
+	"encoding/hex" // Пакет для кодирования/декодирования шестнадцатеричных строк
+	"fmt"          // Пакет для форматирования имен подтестов
+	"strings"      // Пакет для работы со строками
+	"testing"      // Пакет для написания тестов
+)
+
+// hashVector описывает известный ответ хеш-функции
+type hashVector struct {
+	param  string // Имя набора параметров
+	name   string // Название примера
+	msg    string // Сообщение или его повторяемая часть
+	repeat int    // Число повторов msg
+	digest string // Ожидаемое хеш-значение в шестнадцатеричном виде
+}
+
+// Примеры из ГОСТ Р 34.11-94 и RFC 5831 для тестового набора параметров и
+// набора CryptoPro
+var hashVectors = []hashVector{
+	{"test", "пустая строка", "", 1, "ce85b99cc46752fffee35cab9a7b0278abb4c2d2055cff685af4912c49490f8d"},
+	{"test", "a", "a", 1, "d42c539e367c66e9c88a801f6649349c21871b4344c6a573f849fdce62f314dd"},
+	{"test", "abc", "abc", 1, "f3134348c44fb1b2a277729e2285ebb5cb5e0f29c975bc753b70497c06a4d51d"},
+	{"test", "message digest", "message digest", 1, "ad4434ecb18f2c99b60cbe59ec3d2469582b65273f48de72db2fde16a4889a4d"},
+	{"test", "32 байта", "This is message, length=32 bytes", 1, "b1c466d37519b82e8319819ff32595e047a28cb6f83eff1c6916a815a637fffa"},
+	{"test", "50 байт", "Suppose the original message has length = 50 bytes", 1, "471aba57a60a770d3a76130635c1fbea4ef14de51f78b4ae57dd893b62f55208"},
+	{"test", "128 U", "U", 128, "53a3a3ed25180cef0c1d85a074273e551c25660a87062a52d926a9e8fe5733a4"},
+	{"test", "миллион a", "a", 1000000, "5c00ccc2734cdd3332d3d4749576e3c1a7dbaf0e7ea74e9fa602413c90a129fa"},
+	{"test", "lazy dog", "The quick brown fox jumps over the lazy dog", 1, "77b7fa410c9ac58a25f49bca7d0468c9296529315eaca76bd1a10f376d1f4294"},
+	{"cryptopro", "пустая строка", "", 1, "981e5f3ca30c841487830f84fb433e13ac1101569b9c13584ac483234cd656c0"},
+	{"cryptopro", "a", "a", 1, "e74c52dd282183bf37af0079c9f78055715a103f17e3133ceff1aacf2f403011"},
+	{"cryptopro", "abc", "abc", 1, "b285056dbf18d7392d7677369524dd14747459ed8143997e163b2986f92fd42c"},
+	{"cryptopro", "message digest", "message digest", 1, "bc6041dd2aa401ebfa6e9886734174febdb4729aa972d60f549ac39b29721ba0"},
+	{"cryptopro", "32 байта", "This is message, length=32 bytes", 1, "2cefc2f7b7bdc514e18ea57fa74ff357e7fa17d652c75f69cb1be7893ede48eb"},
+	{"cryptopro", "50 байт", "Suppose the original message has length = 50 bytes", 1, "c3730c5cbccacf915ac292676f21e8bd4ef75331d9405e5f1a61dc3130a65011"},
+	{"cryptopro", "128 U", "U", 128, "1c4ac7614691bbf427fa2316216be8f10d92edfd37cd1027514c1008f649c4e8"},
+	{"cryptopro", "миллион a", "a", 1000000, "8693287aa62f9478f7cb312ec0866b6c4e4a0f11160441e8f4ffcd2715dd554f"},
+	{"cryptopro", "lazy dog", "The quick brown fox jumps over the lazy dog", 1, "9004294a361a508c586fe53d1f1b02746765e71b765472786e4770d565830a76"},
+}
+
+// chunkSizes возвращает размеры порций, которыми сообщение длиной n
+// передается в Write: все варианты выравнивания относительно двух блоков
+// для коротких сообщений и граничные размеры для длинных
+func chunkSizes(n int) []int {
+	if n > 4*BlockSize {
+		return []int{n, 1, BlockSize - 1, BlockSize, BlockSize + 1, 4096}
+	}
+	sizes := []int{n}
+	for c := 1; c <= 2*BlockSize+1 && c < n; c++ {
+		sizes = append(sizes, c)
+	}
+	return sizes
+}
+
+// TestKAT проверяет хеш-значения известных примеров при всех вариантах
+// разбиения сообщения на порции
+func TestKAT(t *testing.T) {
+	for _, v := range hashVectors {
+		t.Run(v.param+"/"+v.name, func(t *testing.T) {
+			p, err := ParamSetByName(v.param)
+			if err != nil {
+				t.Fatal(err)
+			}
+			msg := []byte(strings.Repeat(v.msg, v.repeat))
+			want, _ := hex.DecodeString(v.digest)
+			h := New(p.Sbox)
+			for _, c := range chunkSizes(len(msg)) {
+				t.Run(fmt.Sprint(c), func(t *testing.T) {
+					h.Reset()
+					for data := msg; len(data) > 0; {
+						n := min(c, len(data))
+						h.Write(data[:n])
+						data = data[n:]
+					}
+					if got := h.Sum(nil); !bytes.Equal(got, want) {
+						t.Errorf("порции по %d байт: получено %x, ожидалось %s", c, got, v.digest)
+					}
+				})
+			}
+		})
+	}
+}
+
+func TestSelfTest(t *testing.T) {
+	if err := SelfTest(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// Копия, созданная Clone, и оригинал продолжают хеширование независимо
+func TestClone(t *testing.T) {
+	prefix := testMessage(2*BlockSize + 5)
+	suffix1 := []byte("первое продолжение сообщения длиной больше блока")
+	suffix2 := []byte("второе")
+	// sum возвращает хеш-значение сообщения из частей parts
+	sum := func(parts ...[]byte) []byte {
+		h := New(&SboxIdGostR341194CryptoProParamSet)
+		for _, p := range parts {
+			h.Write(p)
+		}
+		return h.Sum(nil)
+	}
+
+	// Префикс кратен блоку (буфер пуст) и с байтами в буфере
+	for _, n := range []int{2 * BlockSize, len(prefix)} {
+		h := New(&SboxIdGostR341194CryptoProParamSet)
+		h.Write(prefix[:n])
+		c := h.Clone()
+		h.Write(suffix1)
+		c.Write(suffix2)
+		if got, want := h.Sum(nil), sum(prefix[:n], suffix1); !bytes.Equal(got, want) {
+			t.Errorf("префикс %d байт, оригинал: получено %x, ожидалось %x", n, got, want)
+		}
+		if got, want := c.Sum(nil), sum(prefix[:n], suffix2); !bytes.Equal(got, want) {
+			t.Errorf("префикс %d байт, копия: получено %x, ожидалось %x", n, got, want)
+		}
+
+		// Reset копии не затрагивает оригинал
+		c.Reset()
+		if got, want := h.Sum(nil), sum(prefix[:n], suffix1); !bytes.Equal(got, want) {
+			t.Errorf("префикс %d байт: Reset копии изменил оригинал", n)
+		}
+	}
+}
+
+// NewWithIV начинает хеширование с заданного значения, к которому
+// возвращается Reset
+func TestNewWithIV(t *testing.T) {
+	msg := testMessage(BlockSize + 3)
+	sbox := &SboxIdGostR341194CryptoProParamSet
+	ref := New(sbox)
+	ref.Write(msg)
+	std := ref.Sum(nil)
+
+	var zero [Size]byte
+	h := NewWithIV(sbox, &zero)
+	h.Write(msg)
+	if got := h.Sum(nil); !bytes.Equal(got, std) {
+		t.Errorf("нулевое начальное значение: получено %x, ожидалось %x", got, std)
+	}
+
+	var iv [Size]byte
+	for i := range iv {
+		iv[i] = byte(0xa0 + i)
+	}
+	h = NewWithIV(sbox, &iv)
+	h.Write(msg)
+	custom := h.Sum(nil)
+	if bytes.Equal(custom, std) {
+		t.Error("ненулевое начальное значение не изменило результат")
+	}
+	h.Reset()
+	h.Write(msg)
+	if got := h.Sum(nil); !bytes.Equal(got, custom) {
+		t.Errorf("после Reset: получено %x, ожидалось %x", got, custom)
+	}
+
+	// ParamSet.New использует начальное значение набора
+	p := ParamSet{Name: "iv", Sbox: sbox, IV: iv}
+	h = p.New()
+	h.Write(msg)
+	if got := h.Sum(nil); !bytes.Equal(got, custom) {
+		t.Errorf("ParamSet.New: получено %x, ожидалось %x", got, custom)
+	}
+
+	// Состояние не переносится между хеш-функциями с разными начальными
+	// значениями
+	state, err := h.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := New(sbox).UnmarshalBinary(state); err != errStateIV {
+		t.Errorf("UnmarshalBinary: ошибка %v, ожидалась %v", err, errStateIV)
+	}
+	if err := NewWithIV(sbox, &iv).UnmarshalBinary(state); err != nil {
+		t.Errorf("UnmarshalBinary с тем же начальным значением: %v", err)
+	}
+}
+
+func TestWriteBits(t *testing.T) {
+	msg := []byte("Suppose the original message has length = 50 bytes")
+	h := New(&SboxIdGostR341194TestParamSet)
+	h.Write(msg)
+	want := h.Sum(nil)
+
+	// Запись целыми байтами через WriteBits совпадает с Write
+	h.Reset()
+	if err := h.WriteBits(msg, uint64(len(msg))*8); err != nil {
+		t.Fatal(err)
+	}
+	if got := h.Sum(nil); !bytes.Equal(got, want) {
+		t.Errorf("WriteBits: получено %x, ожидалось %x", got, want)
+	}
+
+	// Старшие биты неполного байта отбрасываются
+	h.Reset()
+	h.WriteBits([]byte{0xa5}, 4)
+	got := h.Sum(nil)
+	h.Reset()
+	h.WriteBits([]byte{0x05}, 4)
+	if want := h.Sum(nil); !bytes.Equal(got, want) {
+		t.Errorf("старшие биты неполного байта влияют на результат")
+	}
+
+	h.Reset()
+	if err := h.WriteBits(msg, 1000); err != errShortBits {
+		t.Errorf("недостаточно данных: ошибка %v, ожидалась %v", err, errShortBits)
+	}
+}
+
+func TestWriteAfterPartialByte(t *testing.T) {
+	h := New(&SboxIdGostR341194TestParamSet)
+	h.WriteBits([]byte{0x05}, 3)
+	if err := h.WriteBits([]byte{0}, 8); err != errPartialByte {
+		t.Errorf("WriteBits: ошибка %v, ожидалась %v", err, errPartialByte)
+	}
+	func() {
+		defer func() {
+			if r := recover(); r != errPartialByte {
+				t.Errorf("Write: паника %v, ожидалась %v", r, errPartialByte)
+			}
+		}()
+		h.Write([]byte{0})
+	}()
+
+	// После Reset запись снова допустима
+	h.Reset()
+	h.Write([]byte{0})
+}
+
+func TestWriteAllocs(t *testing.T) {
+	h := New(&SboxIdGostR341194CryptoProParamSet)
+	data := make([]byte, 8<<10+BlockSize/2)
+	if n := testing.AllocsPerRun(100, func() { h.Write(data) }); n != 0 {
+		t.Errorf("Write: %v выделений памяти, ожидалось 0", n)
+	}
+}
+
+func TestSumAllocs(t *testing.T) {
+	h := New(&SboxIdGostR341194CryptoProParamSet)
+	h.Write(make([]byte, BlockSize+BlockSize/2))
+	out := make([]byte, 0, Size)
+	if n := testing.AllocsPerRun(100, func() { h.Sum(out[:0]) }); n != 0 {
+		t.Errorf("Sum: %v выделений памяти, ожидалось 0", n)
+	}
+}
+
+func BenchmarkWrite(b *testing.B) {
+	h := New(&SboxIdGostR341194CryptoProParamSet)
+	data := make([]byte, 8<<10)
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		h.Write(data)
+	}
+}
+
+func BenchmarkHash(b *testing.B) {
+	h := New(&SboxIdGostR341194CryptoProParamSet)
+	data := make([]byte, 8<<10)
+	out := make([]byte, 0, Size)
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		h.Reset()
+		h.Write(data)
+		out = h.Sum(out[:0])
+	}
+}
@@ -0,0 +1,17 @@
+package gost341194
+
+import (
+	"crypto/hmac" // Пакет с реализацией HMAC
+	"hash"        // Пакет с общим интерфейсом хеш-функций
+)
+
+// NewHMAC создает HMAC-GOSTR3411-94 (RFC 4357) с ключом key и указанными S-блоками
+// Размер блока HMAC равен размеру блока хеш-функции (32 байта)
+func NewHMAC(key []byte, sbox *Sbox) hash.Hash {
+	return hmac.New(func() hash.Hash { return New(sbox) }, key)
+}
+
+// Equal сравнивает два значения имитовставки за постоянное время
+func Equal(mac1, mac2 []byte) bool {
+	return hmac.Equal(mac1, mac2)
+}
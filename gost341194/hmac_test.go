@@ -0,0 +1,47 @@
+package gost341194
+
+import (
+	"encoding/hex" // Пакет для кодирования/декодирования шестнадцатеричных строк
+	"testing"      // Пакет для написания тестов
+)
+
+// Первый блок PBKDF2 с одной итерацией равен HMAC(password, salt || INT(1)),
+// поэтому ожидаемое значение взято из примера ТК 26 «Дополнение к PKCS#5»
+// для пароля "password", соли "salt" и одной итерации
+const hmacVector = "7314e7c04fb2e662c543674253f68bd0b73445d07f241bed872882da21662d58"
+
+func TestHMAC(t *testing.T) {
+	want, _ := hex.DecodeString(hmacVector)
+	mac := NewHMAC([]byte("password"), &SboxIdGostR341194CryptoProParamSet)
+	if mac.BlockSize() != BlockSize || mac.Size() != Size {
+		t.Fatalf("размер блока %d и значения %d, ожидалось %d и %d", mac.BlockSize(), mac.Size(), BlockSize, Size)
+	}
+	mac.Write([]byte("salt\x00\x00\x00\x01"))
+	if got := mac.Sum(nil); !Equal(got, want) {
+		t.Errorf("получено %x, ожидалось %s", got, hmacVector)
+	}
+
+	// После Reset имитовставка вычисляется заново, порциями по одному байту
+	mac.Reset()
+	for _, b := range []byte("salt\x00\x00\x00\x01") {
+		mac.Write([]byte{b})
+	}
+	if got := mac.Sum(nil); !Equal(got, want) {
+		t.Errorf("после Reset получено %x, ожидалось %s", got, hmacVector)
+	}
+}
+
+func TestEqual(t *testing.T) {
+	a, _ := hex.DecodeString(hmacVector)
+	b := append([]byte(nil), a...)
+	if !Equal(a, b) {
+		t.Error("равные значения признаны разными")
+	}
+	b[Size-1] ^= 1
+	if Equal(a, b) {
+		t.Error("значения, различающиеся последним битом, признаны равными")
+	}
+	if Equal(a, a[:Size-1]) {
+		t.Error("значения разной длины признаны равными")
+	}
+}
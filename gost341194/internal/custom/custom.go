@@ -0,0 +1,22 @@
+// Пакет custom передает пакету research конструктор хеш-функции
+// gost341194 с нестандартной функцией сжатия, не добавляя его в публичный
+// API gost341194
+package custom
+
+import (
+	"hash" // Пакет с интерфейсом hash.Hash
+
+	"main/gost341194/compress" // Функция сжатия ГОСТ Р 34.11-94
+)
+
+// Hash - хеш-функция, построенная по схеме ГОСТ Р 34.11-94 с произвольной
+// функцией сжатия
+type Hash interface {
+	hash.Hash
+	SetTracer(t compress.Tracer)
+}
+
+// New создает хеш-функцию по схеме ГОСТ Р 34.11-94 (остаток сообщения, блок
+// длины и контрольная сумма) с функцией сжатия cmp. Устанавливается пакетом
+// gost341194 при инициализации
+var New func(sbox *compress.Sbox, cmp *compress.Compressor) Hash
@@ -0,0 +1,107 @@
+package gost341194
+
+import (
+	"bytes"           // Пакет для сравнения байтовых срезов
+	"encoding/binary" // Пакет для работы с бинарными данными
+	"errors"          // Пакет для создания ошибок
+)
+
+const (
+	magic    = "g94\x03"  // Сигнатура и версия формата сохраненного состояния
+	sboxSize = 8 * 16 / 2 // Размер упакованных S-блоков: по 4 бита на элемент
+
+	// Размер сохраненного состояния: сигнатура, S-блоки, начальное значение,
+	// хеш, контрольная сумма, счетчик битов, буфер, длина данных в буфере
+	// и число битов неполного байта
+	marshaledSize = len(magic) + sboxSize + BlockSize + BlockSize + BlockSize + 8 + BlockSize + 1 + 1
+)
+
+var (
+	errStateID   = errors.New("gost341194: неверный идентификатор состояния хеш-функции")
+	errStateSize = errors.New("gost341194: неверный размер состояния хеш-функции")
+	errStateSbox = errors.New("gost341194: состояние сохранено с другими S-блоками")
+	errStateIV   = errors.New("gost341194: состояние сохранено с другим начальным значением хеша")
+	errStateBuf  = errors.New("gost341194: неверная длина буфера в состоянии хеш-функции")
+	errNoSbox    = errors.New("gost341194: S-блоки хеш-функции не заданы")
+	errCustom    = errors.New("gost341194: состояние с нестандартной функцией сжатия не сохраняется")
+)
+
+// appendSbox упаковывает S-блоки по два элемента в байт и добавляет их к b
+func appendSbox(b []byte, sbox *Sbox) []byte {
+	for i := range sbox {
+		for j := 0; j < len(sbox[i]); j += 2 {
+			b = append(b, sbox[i][j]<<4|sbox[i][j+1]&0x0f)
+		}
+	}
+	return b
+}
+
+// MarshalBinary сохраняет текущее состояние хеш-функции
+// Реализует интерфейс encoding.BinaryMarshaler
+func (h *Hash) MarshalBinary() ([]byte, error) {
+	return h.AppendBinary(make([]byte, 0, marshaledSize))
+}
+
+// AppendBinary добавляет сохраненное состояние хеш-функции к b
+// Реализует интерфейс encoding.BinaryAppender
+func (h *Hash) AppendBinary(b []byte) ([]byte, error) {
+	if h.sbox == nil {
+		return nil, errNoSbox
+	}
+	// Формат не хранит параметры функции сжатия: стандартная хеш-функция
+	// восстановила бы такое состояние без ошибки и выдала неверный результат
+	if h.custom {
+		return nil, errCustom
+	}
+	b = append(b, magic...)
+	b = appendSbox(b, h.sbox)
+	b = append(b, h.iv[:]...)
+	b = append(b, h.hsh[:]...)
+	b = append(b, h.chk[:]...)
+	b = binary.BigEndian.AppendUint64(b, h.size)
+	// Буфер дополняется нулями до полного блока
+	b = append(b, h.buf[:h.n]...)
+	b = append(b, make([]byte, BlockSize-h.n)...)
+	b = append(b, byte(h.n))
+	b = append(b, h.bits)
+	return b, nil
+}
+
+// UnmarshalBinary восстанавливает состояние хеш-функции, сохраненное MarshalBinary
+// Реализует интерфейс encoding.BinaryUnmarshaler
+func (h *Hash) UnmarshalBinary(b []byte) error {
+	if len(b) < len(magic) || string(b[:len(magic)]) != magic {
+		return errStateID
+	}
+	if len(b) != marshaledSize {
+		return errStateSize
+	}
+	if h.sbox == nil {
+		return errNoSbox
+	}
+	b = b[len(magic):]
+	// Состояние можно восстановить только с теми же S-блоками
+	if !bytes.Equal(b[:sboxSize], appendSbox(nil, h.sbox)) {
+		return errStateSbox
+	}
+	b = b[sboxSize:]
+	// и с тем же начальным значением, к которому вернется Reset
+	if !bytes.Equal(b[:BlockSize], h.iv[:]) {
+		return errStateIV
+	}
+	b = b[BlockSize:]
+	n := int(b[BlockSize+BlockSize+8+BlockSize])
+	bits := b[BlockSize+BlockSize+8+BlockSize+1]
+	if n >= BlockSize || bits >= 8 {
+		return errStateBuf
+	}
+	copy(h.hsh[:], b[:BlockSize])
+	b = b[BlockSize:]
+	copy(h.chk[:], b[:BlockSize])
+	b = b[BlockSize:]
+	h.size = binary.BigEndian.Uint64(b)
+	b = b[8:]
+	h.n = copy(h.buf[:], b[:n])
+	h.bits = bits
+	return nil
+}
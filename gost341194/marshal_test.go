@@ -0,0 +1,129 @@
+package gost341194
+
+import (
+	"bytes"   // Пакет для сравнения байтовых срезов
+	"testing" // Пакет для написания тестов
+)
+
+// testMessage возвращает сообщение длиной n с неповторяющимися байтами
+func testMessage(n int) []byte {
+	msg := make([]byte, n)
+	for i := range msg {
+		msg[i] = byte(i*7 + 1)
+	}
+	return msg
+}
+
+// Состояние, сохраненное после любого префикса, восстанавливается и дает
+// то же хеш-значение
+func TestMarshalRoundTrip(t *testing.T) {
+	msg := testMessage(3*BlockSize + 1)
+	ref := New(&SboxIdGostR341194CryptoProParamSet)
+	ref.Write(msg)
+	want := ref.Sum(nil)
+
+	for k := 0; k <= len(msg); k++ {
+		h := New(&SboxIdGostR341194CryptoProParamSet)
+		h.Write(msg[:k])
+		state, err := h.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(state) != marshaledSize {
+			t.Fatalf("размер состояния %d, ожидалось %d", len(state), marshaledSize)
+		}
+		r := New(&SboxIdGostR341194CryptoProParamSet)
+		if err := r.UnmarshalBinary(state); err != nil {
+			t.Fatalf("%d байт: %v", k, err)
+		}
+		r.Write(msg[k:])
+		if got := r.Sum(nil); !bytes.Equal(got, want) {
+			t.Errorf("сохранение после %d байт: получено %x, ожидалось %x", k, got, want)
+		}
+	}
+}
+
+// Неполный байт WriteBits сохраняется вместе с состоянием
+func TestMarshalPartialByte(t *testing.T) {
+	msg := testMessage(BlockSize + 2)
+	nbits := uint64(BlockSize+1)*8 + 3
+	h := New(&SboxIdGostR341194TestParamSet)
+	if err := h.WriteBits(msg, nbits); err != nil {
+		t.Fatal(err)
+	}
+	want := h.Sum(nil)
+	state, err := h.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := New(&SboxIdGostR341194TestParamSet)
+	if err := r.UnmarshalBinary(state); err != nil {
+		t.Fatal(err)
+	}
+	if got := r.Sum(nil); !bytes.Equal(got, want) {
+		t.Errorf("получено %x, ожидалось %x", got, want)
+	}
+	if err := r.WriteBits(msg, 8); err != errPartialByte {
+		t.Errorf("WriteBits после восстановления: ошибка %v, ожидалась %v", err, errPartialByte)
+	}
+}
+
+func TestUnmarshalErrors(t *testing.T) {
+	h := New(&SboxIdGostR341194TestParamSet)
+	h.Write(testMessage(BlockSize + 5))
+	state, err := h.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// corrupt возвращает копию состояния, измененную функцией f
+	corrupt := func(f func(b []byte) []byte) []byte {
+		return f(append([]byte(nil), state...))
+	}
+	var iv [Size]byte
+	iv[0] = 1
+
+	tests := []struct {
+		name  string
+		h     *Hash
+		state []byte
+		err   error
+	}{
+		{"сигнатура", New(&SboxIdGostR341194TestParamSet), corrupt(func(b []byte) []byte { b[0] ^= 1; return b }), errStateID},
+		{"короткое", New(&SboxIdGostR341194TestParamSet), state[:2], errStateID},
+		{"размер", New(&SboxIdGostR341194TestParamSet), state[:len(state)-1], errStateSize},
+		{"лишние данные", New(&SboxIdGostR341194TestParamSet), corrupt(func(b []byte) []byte { return append(b, 0) }), errStateSize},
+		{"S-блоки", New(&SboxIdGostR341194CryptoProParamSet), state, errStateSbox},
+		{"начальное значение", NewWithIV(&SboxIdGostR341194TestParamSet, &iv), state, errStateIV},
+		{"длина буфера", New(&SboxIdGostR341194TestParamSet), corrupt(func(b []byte) []byte { b[len(b)-2] = BlockSize; return b }), errStateBuf},
+		{"неполный байт", New(&SboxIdGostR341194TestParamSet), corrupt(func(b []byte) []byte { b[len(b)-1] = 8; return b }), errStateBuf},
+		{"без S-блоков", &Hash{}, state, errNoSbox},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.h.UnmarshalBinary(tt.state); err != tt.err {
+				t.Errorf("ошибка %v, ожидалась %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestAppendBinary(t *testing.T) {
+	h := New(&SboxIdGostR341194CryptoProParamSet)
+	h.Write(testMessage(BlockSize + 7))
+	want, err := h.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	prefix := []byte("prefix")
+	b, err := h.AppendBinary(append([]byte(nil), prefix...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(b, prefix) || !bytes.Equal(b[len(prefix):], want) {
+		t.Errorf("получено %x, ожидалось %x после %q", b, want, prefix)
+	}
+	if _, err := new(Hash).AppendBinary(nil); err != errNoSbox {
+		t.Errorf("без S-блоков: ошибка %v, ожидалась %v", err, errNoSbox)
+	}
+}
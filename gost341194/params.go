@@ -0,0 +1,79 @@
+package gost341194
+
+import (
+	"encoding/asn1" // Пакет для кодирования структур ASN.1 DER
+	"fmt"           // Пакет для форматирования сообщений об ошибках
+	"strings"       // Пакет для работы со строками
+)
+
+// ParamSet описывает именованный набор параметров хеш-функции
+type ParamSet struct {
+	Name string                // Короткое имя набора для командной строки и веб-интерфейса
+	OID  asn1.ObjectIdentifier // Идентификатор объекта ASN.1 (nil - набор выбирается только по имени)
+	Sbox *Sbox                 // S-блоки для алгоритма ГОСТ 28147-89
+	IV   [Size]byte            // Начальное значение хеша в порядке байтов результата Sum
+}
+
+// Наборы параметров, поддерживаемые пакетом; стандартные наборы используют
+// нулевое начальное значение хеша
+var paramSets = []ParamSet{
+	// id-GostR3411-94-TestParamSet (RFC 4357)
+	{Name: "test", OID: asn1.ObjectIdentifier{1, 2, 643, 2, 2, 30, 0}, Sbox: &SboxIdGostR341194TestParamSet},
+	// id-GostR3411-94-CryptoProParamSet (RFC 4357), используется CryptoPro CSP и OpenSSL
+	{Name: "cryptopro", OID: asn1.ObjectIdentifier{1, 2, 643, 2, 2, 30, 1}, Sbox: &SboxIdGostR341194CryptoProParamSet},
+	// S-блоки id-tc26-gost-28147-param-Z (RFC 7836). Это набор параметров
+	// шифра ГОСТ 28147-89, а не хеш-функции: стандартного OID для хеш-функции
+	// с этими S-блоками нет, поэтому набор выбирается только по имени
+	{Name: "tc26-z", Sbox: &SboxIdtc26gost28147paramZ},
+}
+
+// ParamSets возвращает список всех поддерживаемых наборов параметров
+func ParamSets() []ParamSet {
+	return append([]ParamSet(nil), paramSets...)
+}
+
+// ParamSetByName ищет набор параметров по имени без учета регистра
+func ParamSetByName(name string) (*ParamSet, error) {
+	for i := range paramSets {
+		if strings.EqualFold(paramSets[i].Name, name) {
+			p := paramSets[i]
+			return &p, nil
+		}
+	}
+	return nil, fmt.Errorf("gost341194: неизвестный набор параметров %q", name)
+}
+
+// ParamSetByOID ищет набор параметров по идентификатору объекта в точечной
+// записи
+func ParamSetByOID(oid string) (*ParamSet, error) {
+	for i := range paramSets {
+		if paramSets[i].OID != nil && paramSets[i].OID.String() == oid {
+			p := paramSets[i]
+			return &p, nil
+		}
+	}
+	return nil, fmt.Errorf("gost341194: неизвестный OID набора параметров %s", oid)
+}
+
+// NewByName создает хеш-функцию с набором параметров, выбранным по имени
+func NewByName(name string) (*Hash, error) {
+	p, err := ParamSetByName(name)
+	if err != nil {
+		return nil, err
+	}
+	return p.New(), nil
+}
+
+// NewByOID создает хеш-функцию с набором параметров, выбранным по OID
+func NewByOID(oid string) (*Hash, error) {
+	p, err := ParamSetByOID(oid)
+	if err != nil {
+		return nil, err
+	}
+	return p.New(), nil
+}
+
+// New создает хеш-функцию с S-блоками и начальным значением набора параметров
+func (p *ParamSet) New() *Hash {
+	return NewWithIV(p.Sbox, &p.IV)
+}
@@ -0,0 +1,104 @@
+package gost341194
+
+import (
+	"bytes"   // Пакет для сравнения байтовых срезов
+	"testing" // Пакет для написания тестов
+)
+
+func TestParamSets(t *testing.T) {
+	sets := ParamSets()
+	var names []string
+	for _, p := range sets {
+		names = append(names, p.Name)
+	}
+	if len(sets) != 3 || names[0] != "test" || names[1] != "cryptopro" || names[2] != "tc26-z" {
+		t.Fatalf("наборы %v, ожидались [test cryptopro tc26-z]", names)
+	}
+	// Возвращается копия: изменение результата не затрагивает реестр
+	sets[0].Name = "changed"
+	if p, err := ParamSetByName("test"); err != nil || p.Name != "test" {
+		t.Errorf("реестр изменен через результат ParamSets: %v, %v", p, err)
+	}
+}
+
+func TestParamSetByName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"test", "test"},
+		{"TEST", "test"},
+		{"cryptopro", "cryptopro"},
+		{"CryptoPro", "cryptopro"},
+		{"TC26-Z", "tc26-z"},
+	}
+	for _, tt := range tests {
+		p, err := ParamSetByName(tt.name)
+		if err != nil || p.Name != tt.want {
+			t.Errorf("%q: получено %v (%v), ожидалось %s", tt.name, p, err, tt.want)
+		}
+	}
+	for _, name := range []string{"", "unknown", "cryptopro "} {
+		if _, err := ParamSetByName(name); err == nil {
+			t.Errorf("%q: ошибка не возвращена", name)
+		}
+	}
+}
+
+func TestParamSetByOID(t *testing.T) {
+	tests := []struct {
+		oid  string
+		want string
+	}{
+		{"1.2.643.2.2.30.0", "test"},
+		{"1.2.643.2.2.30.1", "cryptopro"},
+	}
+	for _, tt := range tests {
+		p, err := ParamSetByOID(tt.oid)
+		if err != nil || p.Name != tt.want {
+			t.Errorf("%s: получено %v (%v), ожидалось %s", tt.oid, p, err, tt.want)
+		}
+	}
+	// tc26-z не имеет OID хеш-функции, OID набора шифра не подходит
+	for _, oid := range []string{"", "1.2.643.7.1.2.5.1.1", "1.2.643.2.2.30.2", "1.2.643.2.2.30"} {
+		if _, err := ParamSetByOID(oid); err == nil {
+			t.Errorf("%q: ошибка не возвращена", oid)
+		}
+	}
+}
+
+// NewByName и NewByOID создают хеш-функцию с S-блоками набора
+func TestNewByNameOID(t *testing.T) {
+	msg := []byte("The quick brown fox jumps over the lazy dog")
+	for _, p := range ParamSets() {
+		ref := New(p.Sbox)
+		ref.Write(msg)
+		want := ref.Sum(nil)
+
+		h, err := NewByName(p.Name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		h.Write(msg)
+		if got := h.Sum(nil); !bytes.Equal(got, want) {
+			t.Errorf("NewByName(%q): получено %x, ожидалось %x", p.Name, got, want)
+		}
+
+		if p.OID == nil {
+			continue
+		}
+		if h, err = NewByOID(p.OID.String()); err != nil {
+			t.Fatal(err)
+		}
+		h.Write(msg)
+		if got := h.Sum(nil); !bytes.Equal(got, want) {
+			t.Errorf("NewByOID(%s): получено %x, ожидалось %x", p.OID, got, want)
+		}
+	}
+	if _, err := NewByName("unknown"); err == nil {
+		t.Error("NewByName: ошибка не возвращена для неизвестного имени")
+	}
+	if _, err := NewByOID("1.2.3"); err == nil {
+		t.Error("NewByOID: ошибка не возвращена для неизвестного OID")
+	}
+}
@@ -0,0 +1,50 @@
+package gost341194
+
+import (
+	"encoding/binary" // Пакет для работы с бинарными данными
+	"errors"          // Пакет для создания ошибок
+)
+
+var (
+	errPBKDF2Iter   = errors.New("gost341194: число итераций PBKDF2 должно быть положительным")
+	errPBKDF2KeyLen = errors.New("gost341194: длина ключа PBKDF2 должна быть положительной")
+)
+
+// PBKDF2 вырабатывает ключ длиной keyLen байт из пароля по PKCS#5 PBKDF2
+// с псевдослучайной функцией HMAC-GOSTR3411-94 (RFC 4357, TC26 Addition to PKCS#5)
+func PBKDF2(password, salt []byte, iter, keyLen int, sbox *Sbox) ([]byte, error) {
+	if iter < 1 {
+		return nil, errPBKDF2Iter
+	}
+	if keyLen < 1 {
+		return nil, errPBKDF2KeyLen
+	}
+
+	prf := NewHMAC(password, sbox)
+	numBlocks := (keyLen + Size - 1) / Size
+	dk := make([]byte, 0, numBlocks*Size)
+	u := make([]byte, 0, Size)
+	var index [4]byte
+
+	for block := 1; block <= numBlocks; block++ {
+		// U1 = PRF(password, salt || INT(block))
+		prf.Reset()
+		prf.Write(salt)
+		binary.BigEndian.PutUint32(index[:], uint32(block))
+		prf.Write(index[:])
+		dk = prf.Sum(dk)
+		t := dk[len(dk)-Size:]
+		u = append(u[:0], t...)
+
+		// Ti = U1 ^ U2 ^ ... ^ Uiter
+		for n := 2; n <= iter; n++ {
+			prf.Reset()
+			prf.Write(u)
+			u = prf.Sum(u[:0])
+			for i := range u {
+				t[i] ^= u[i]
+			}
+		}
+	}
+	return dk[:keyLen], nil
+}
@@ -0,0 +1,53 @@
+package gost341194
+
+import (
+	"encoding/hex" // Пакет для кодирования/декодирования шестнадцатеричных строк
+	"fmt"          // Пакет для форматирования имен подтестов
+	"testing"      // Пакет для написания тестов
+)
+
+// pbkdf2Vector описывает известный ответ PBKDF2 с набором параметров CryptoPro
+type pbkdf2Vector struct {
+	password string // Пароль
+	salt     string // Соль
+	iter     int    // Число итераций
+	key      string // Ожидаемый ключ в шестнадцатеричном виде
+}
+
+// Примеры из ТК 26 «Дополнение к PKCS#5» (Addition to PKCS#5 v1.0)
+var pbkdf2Vectors = []pbkdf2Vector{
+	{"password", "salt", 1, "7314e7c04fb2e662c543674253f68bd0b73445d07f241bed872882da21662d58"},
+	{"password", "salt", 2, "990dfa2bd965639ba48b07b792775df79f2db34fef25f274378872fed7ed1bb3"},
+	{"password", "salt", 4096, "1f1829a94bdff5be10d0aeb36af498e7a97467f3b31116a5a7c1afff9deadafe"},
+	{"passwordPASSWORDpassword", "saltSALTsaltSALTsaltSALTsaltSALTsalt", 4096, "788358c69cb2dbe251a7bb17d5f4241f265a792a35becde8d56f326b49c85047b7638acb4764b1fd"},
+	{"pass\x00word", "sa\x00lt", 4096, "43e06c5590b08c0225242373127edf9c8e9c3291"},
+}
+
+func TestPBKDF2(t *testing.T) {
+	for _, v := range pbkdf2Vectors {
+		t.Run(fmt.Sprintf("%q/%q/%d", v.password, v.salt, v.iter), func(t *testing.T) {
+			want, _ := hex.DecodeString(v.key)
+			got, err := PBKDF2([]byte(v.password), []byte(v.salt), v.iter, len(want), &SboxIdGostR341194CryptoProParamSet)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !Equal(got, want) {
+				t.Errorf("получено %x, ожидалось %s", got, v.key)
+			}
+		})
+	}
+}
+
+func TestPBKDF2InvalidArgs(t *testing.T) {
+	sbox := &SboxIdGostR341194CryptoProParamSet
+	for _, iter := range []int{0, -1} {
+		if _, err := PBKDF2([]byte("password"), []byte("salt"), iter, Size, sbox); err != errPBKDF2Iter {
+			t.Errorf("iter=%d: ошибка %v, ожидалась %v", iter, err, errPBKDF2Iter)
+		}
+	}
+	for _, keyLen := range []int{0, -1} {
+		if _, err := PBKDF2([]byte("password"), []byte("salt"), 1, keyLen, sbox); err != errPBKDF2KeyLen {
+			t.Errorf("keyLen=%d: ошибка %v, ожидалась %v", keyLen, err, errPBKDF2KeyLen)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+// Пакет research содержит НЕСТАНДАРТНЫЕ варианты хеш-функции ГОСТ Р 34.11-94
+// для учебного криптоанализа: число раундов psi, константы выработки ключей
+// и число раундов ГОСТ 28147-89 задаются конфигурацией. Только конфигурация
+// DefaultConfig дает значения ГОСТ Р 34.11-94; для защиты информации
+// используйте gost341194.New
+package research
+
+import (
+	_ "main/gost341194"               // Устанавливает конструктор custom.New
+	"main/gost341194/compress"        // Функция сжатия ГОСТ Р 34.11-94
+	"main/gost341194/internal/custom" // Хеш-функция по схеме ГОСТ Р 34.11-94
+)
+
+const (
+	BlockSize = compress.BlockSize // Размер блока в байтах (256 бит)
+	Size      = compress.BlockSize // Размер хеш-значения в байтах (256 бит)
+)
+
+// Config задает число раундов psi (Leading, Middle, Trailing), константы
+// выработки ключей (KeyConsts) и число раундов шифрования (CipherRounds)
+type Config = compress.Params
+
+// DefaultConfig возвращает стандартную конфигурацию: 12, 1 и 61 раунд psi,
+// константы C2, C3, C4 и 32 раунда ГОСТ 28147-89
+func DefaultConfig() Config {
+	return compress.StandardParams()
+}
+
+// Hash представляет состояние исследовательского варианта хеш-функции.
+// Дополнение сообщения и контрольная сумма вычисляются кодом gost341194,
+// отличается только функция сжатия
+type Hash struct {
+	h custom.Hash
+}
+
+// New создает исследовательский вариант хеш-функции с указанными S-блоками
+// и конфигурацией. Возвращает ошибку при недопустимом числе раундов
+func New(sbox *compress.Sbox, cfg Config) (*Hash, error) {
+	cmp, err := compress.NewCompressorParams(sbox, &cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &Hash{h: custom.New(sbox, cmp)}, nil
+}
+
+// SetTracer устанавливает получателя событий функции сжатия для изучения
+// рассеивания; nil отключает трассировку
+func (h *Hash) SetTracer(t compress.Tracer) {
+	h.h.SetTracer(t)
+}
+
+// Reset сбрасывает состояние хеш-функции до начального
+func (h *Hash) Reset() {
+	h.h.Reset()
+}
+
+// BlockSize возвращает размер блока хеш-функции в байтах
+func (h *Hash) BlockSize() int {
+	return BlockSize
+}
+
+// Size возвращает размер итогового хеш-значения в байтах
+func (h *Hash) Size() int {
+	return Size
+}
+
+// Write добавляет данные к хешируемому сообщению
+// Реализует интерфейс io.Writer
+func (h *Hash) Write(data []byte) (int, error) {
+	return h.h.Write(data)
+}
+
+// Sum добавляет padding и возвращает итоговое хеш-значение по той же схеме,
+// что и ГОСТ Р 34.11-94: остаток сообщения, блок длины и контрольная сумма
+func (h *Hash) Sum(in []byte) []byte {
+	return h.h.Sum(in)
+}
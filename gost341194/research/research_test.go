@@ -0,0 +1,97 @@
+package research
+
+import (
+	"bytes"        // Пакет для сравнения байтовых срезов
+	"encoding"     // Пакет с интерфейсом encoding.BinaryMarshaler
+	"encoding/hex" // Пакет для кодирования/декодирования шестнадцатеричных строк
+	"testing"      // Пакет для написания тестов
+
+	"main/gost341194" // Хеш-функция ГОСТ Р 34.11-94
+)
+
+// Конфигурация DefaultConfig воспроизводит примеры из ГОСТ Р 34.11-94
+// и RFC 5831
+func TestDefaultConfigKAT(t *testing.T) {
+	vectors := []struct {
+		sbox   *gost341194.Sbox
+		msg    string
+		digest string
+	}{
+		{&gost341194.SboxIdGostR341194TestParamSet, "This is message, length=32 bytes", "b1c466d37519b82e8319819ff32595e047a28cb6f83eff1c6916a815a637fffa"},
+		{&gost341194.SboxIdGostR341194TestParamSet, "Suppose the original message has length = 50 bytes", "471aba57a60a770d3a76130635c1fbea4ef14de51f78b4ae57dd893b62f55208"},
+		{&gost341194.SboxIdGostR341194CryptoProParamSet, "This is message, length=32 bytes", "2cefc2f7b7bdc514e18ea57fa74ff357e7fa17d652c75f69cb1be7893ede48eb"},
+		{&gost341194.SboxIdGostR341194CryptoProParamSet, "Suppose the original message has length = 50 bytes", "c3730c5cbccacf915ac292676f21e8bd4ef75331d9405e5f1a61dc3130a65011"},
+	}
+	for _, v := range vectors {
+		h, err := New(v.sbox, DefaultConfig())
+		if err != nil {
+			t.Fatal(err)
+		}
+		h.Write([]byte(v.msg))
+		want, _ := hex.DecodeString(v.digest)
+		if got := h.Sum(nil); !bytes.Equal(got, want) {
+			t.Errorf("%q: получено %x, ожидалось %s", v.msg, got, v.digest)
+		}
+	}
+}
+
+// Конфигурация DefaultConfig дает те же значения, что и gost341194.New, для
+// всех наборов параметров и сообщений длиной от 0 до трех блоков
+func TestDefaultConfigMatchesStandard(t *testing.T) {
+	msg := make([]byte, 3*BlockSize+1)
+	for i := range msg {
+		msg[i] = byte(i*7 + 1)
+	}
+	for _, p := range gost341194.ParamSets() {
+		t.Run(p.Name, func(t *testing.T) {
+			h, err := New(p.Sbox, DefaultConfig())
+			if err != nil {
+				t.Fatal(err)
+			}
+			ref := gost341194.New(p.Sbox)
+			for n := 0; n <= len(msg); n++ {
+				h.Reset()
+				ref.Reset()
+				h.Write(msg[:n])
+				ref.Write(msg[:n])
+				if got, want := h.Sum(nil), ref.Sum(nil); !bytes.Equal(got, want) {
+					t.Errorf("%d байт: получено %x, ожидалось %x", n, got, want)
+				}
+			}
+		})
+	}
+}
+
+// Нестандартная конфигурация меняет результат
+func TestCustomConfigDiffers(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Trailing = 4
+	h, err := New(&gost341194.SboxIdGostR341194TestParamSet, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ref := gost341194.New(&gost341194.SboxIdGostR341194TestParamSet)
+	h.Write([]byte("abc"))
+	ref.Write([]byte("abc"))
+	if bytes.Equal(h.Sum(nil), ref.Sum(nil)) {
+		t.Error("результат с Trailing = 4 совпадает со стандартным")
+	}
+}
+
+// Состояние исследовательского варианта нельзя сохранить и восстановить
+// в стандартной хеш-функции
+func TestNoMarshalBinary(t *testing.T) {
+	h, err := New(&gost341194.SboxIdGostR341194TestParamSet, DefaultConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := any(h).(encoding.BinaryMarshaler); ok {
+		t.Error("research.Hash реализует encoding.BinaryMarshaler")
+	}
+	// Хеш-функция gost341194 внутри отказывается сохранять состояние
+	if m, ok := h.h.(encoding.BinaryMarshaler); ok {
+		if _, err := m.MarshalBinary(); err == nil {
+			t.Error("MarshalBinary сохранил состояние с нестандартной функцией сжатия")
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package gost341194
+
+import (
+	"bytes"        // Пакет для сравнения байтовых срезов
+	"encoding/hex" // Пакет для кодирования/декодирования шестнадцатеричных строк
+	"fmt"          // Пакет для форматирования сообщений об ошибках
+)
+
+// Примеры из ГОСТ Р 34.11-94 и RFC 5831 для самопроверки во время выполнения;
+// полные наборы известных ответов - в тестах пакета
+var selfTestVectors = []struct {
+	sbox   *Sbox  // S-блоки
+	msg    string // Сообщение
+	digest string // Ожидаемое хеш-значение в шестнадцатеричном виде
+}{
+	{&SboxIdGostR341194TestParamSet, "Suppose the original message has length = 50 bytes", "471aba57a60a770d3a76130635c1fbea4ef14de51f78b4ae57dd893b62f55208"},
+	{&SboxIdGostR341194CryptoProParamSet, "Suppose the original message has length = 50 bytes", "c3730c5cbccacf915ac292676f21e8bd4ef75331d9405e5f1a61dc3130a65011"},
+}
+
+// Известные ответы PBKDF2 (пароль "password", соль "salt", две итерации) и
+// HMAC для самопроверки; полные наборы примеров - в тестах пакета
+const (
+	selfTestPBKDF2 = "990dfa2bd965639ba48b07b792775df79f2db34fef25f274378872fed7ed1bb3"
+	selfTestHMAC   = "7314e7c04fb2e662c543674253f68bd0b73445d07f241bed872882da21662d58"
+)
+
+// SelfTest проверяет реализацию во время выполнения на нескольких известных
+// ответах: хеш-значениях для тестового набора параметров и набора CryptoPro,
+// HMAC и PBKDF2. Возвращает ошибку при первом несовпадении
+func SelfTest() error {
+	for _, v := range selfTestVectors {
+		want, _ := hex.DecodeString(v.digest)
+		h := New(v.sbox)
+		h.Write([]byte(v.msg))
+		if got := h.Sum(nil); !bytes.Equal(got, want) {
+			return fmt.Errorf("gost341194: самопроверка %q: получено %x, ожидалось %s", v.msg, got, v.digest)
+		}
+	}
+
+	// Пример ТК 26 «Дополнение к PKCS#5» с двумя итерациями; первый блок
+	// PBKDF2 с одной итерацией равен HMAC(password, salt || INT(1))
+	want, _ := hex.DecodeString(selfTestPBKDF2)
+	got, err := PBKDF2([]byte("password"), []byte("salt"), 2, Size, &SboxIdGostR341194CryptoProParamSet)
+	if err != nil {
+		return err
+	}
+	if !Equal(got, want) {
+		return fmt.Errorf("gost341194: самопроверка PBKDF2: получено %x, ожидалось %s", got, selfTestPBKDF2)
+	}
+	mac := NewHMAC([]byte("password"), &SboxIdGostR341194CryptoProParamSet)
+	mac.Write([]byte("salt\x00\x00\x00\x01"))
+	want, _ = hex.DecodeString(selfTestHMAC)
+	if got := mac.Sum(nil); !Equal(got, want) {
+		return fmt.Errorf("gost341194: самопроверка HMAC: получено %x, ожидалось %s", got, selfTestHMAC)
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package gost341194
+
+import (
+	"main/gost341194/compress" // Функция сжатия ГОСТ Р 34.11-94
+)
+
+// Типы трассировки определены вместе с функцией сжатия
+type (
+	EventKind  = compress.EventKind  // Вид события трассировки
+	Event      = compress.Event      // Промежуточное значение хеш-функции
+	Tracer     = compress.Tracer     // Получатель событий трассировки
+	TracerFunc = compress.TracerFunc // Функция в качестве Tracer
+)
+
+const (
+	EventBlock    = compress.EventBlock    // Поглощен блок сообщения
+	EventLength   = compress.EventLength   // Поглощен блок с длиной сообщения в битах
+	EventChecksum = compress.EventChecksum // Поглощен блок с контрольной суммой
+	EventKey      = compress.EventKey      // Выработан ключ шифрования K1..K4
+	EventEncrypt  = compress.EventEncrypt  // Зашифрован блок текущего хеша по ГОСТ 28147-89
+	EventPsi      = compress.EventPsi      // Выполнен раунд преобразования psi
+)
+
+// SetTracer устанавливает получателя событий трассировки; nil отключает
+// трассировку. Без получателя промежуточные значения не формируются
+func (h *Hash) SetTracer(t Tracer) {
+	h.tracer = t
+	h.cmp.SetTracer(t)
+}
+
+// traceBlock сообщает о поглощении блока m, после которого хеш равен hsh,
+// а контрольная сумма - chk
+func (h *Hash) traceBlock(kind EventKind, m, hsh, chk *[BlockSize]byte) {
+	h.tracer.Trace(&Event{Kind: kind, Block: *m, Hash: *hsh, Checksum: *chk})
+}
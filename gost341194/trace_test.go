@@ -0,0 +1,92 @@
+package gost341194
+
+import (
+	"bytes"   // Пакет для сравнения байтовых срезов
+	"testing" // Пакет для написания тестов
+)
+
+// recorder сохраняет копии полученных событий трассировки
+type recorder struct {
+	events []Event
+}
+
+func (r *recorder) Trace(e *Event) {
+	r.events = append(r.events, *e)
+}
+
+// Каждому шагу хеширования предшествуют 4 ключа, 4 зашифрования и 74 раунда
+// psi функции сжатия
+func TestTracer(t *testing.T) {
+	var r recorder
+	h := New(&SboxIdGostR341194CryptoProParamSet)
+	h.SetTracer(&r)
+	h.Write(testMessage(2*BlockSize + 5))
+	sum := h.Sum(nil)
+
+	// Два полных блока в Write; остаток, длина и контрольная сумма в Sum
+	steps := []EventKind{EventBlock, EventBlock, EventBlock, EventLength, EventChecksum}
+	const perStep = 4 + 4 + 74 + 1
+	if len(r.events) != len(steps)*perStep {
+		t.Fatalf("получено %d событий, ожидалось %d", len(r.events), len(steps)*perStep)
+	}
+	for s, kind := range steps {
+		events := r.events[s*perStep : (s+1)*perStep]
+		for i, e := range events[:perStep-1] {
+			var want EventKind
+			var index int
+			switch {
+			case i < 4:
+				want, index = EventKey, i+1
+			case i < 8:
+				want, index = EventEncrypt, i-4+1
+			default:
+				want, index = EventPsi, i-8+1
+			}
+			if e.Kind != want || e.Index != index {
+				t.Fatalf("шаг %d, событие %d: %v %d, ожидалось %v %d", s, i, e.Kind, e.Index, want, index)
+			}
+		}
+		if e := events[perStep-1]; e.Kind != kind {
+			t.Fatalf("шаг %d: событие %v, ожидалось %v", s, e.Kind, kind)
+		}
+	}
+
+	// Хеш после блока контрольной суммы - результат Sum в порядке стандарта
+	last := r.events[len(r.events)-1]
+	var got [Size]byte
+	blockReverse(got[:], last.Hash[:])
+	if !bytes.Equal(got[:], sum) {
+		t.Errorf("хеш в EventChecksum %x, Sum %x", got, sum)
+	}
+
+	// Без получателя событий результат тот же
+	h.SetTracer(nil)
+	n := len(r.events)
+	if !bytes.Equal(h.Sum(nil), sum) || len(r.events) != n {
+		t.Error("трассировка не отключена или изменила результат")
+	}
+}
+
+// Копия, созданная Clone, сообщает о событиях тому же получателю; замена
+// получателя у копии не затрагивает оригинал
+func TestTracerClone(t *testing.T) {
+	var r recorder
+	h := New(&SboxIdGostR341194TestParamSet)
+	h.SetTracer(&r)
+	c := h.Clone()
+	c.Write(make([]byte, BlockSize))
+	if len(r.events) == 0 || r.events[len(r.events)-1].Kind != EventBlock {
+		t.Fatal("копия не сообщает о событиях получателю оригинала")
+	}
+
+	c.SetTracer(nil)
+	n := len(r.events)
+	c.Write(make([]byte, BlockSize))
+	if len(r.events) != n {
+		t.Error("копия сообщает о событиях после SetTracer(nil)")
+	}
+	h.Write(make([]byte, BlockSize))
+	if len(r.events) == n {
+		t.Error("SetTracer(nil) у копии отключил трассировку оригинала")
+	}
+}
@@ -0,0 +1,84 @@
+// Пакет sumfile разбирает и формирует строки файлов контрольных сумм
+// в формате coreutils (md5sum, sha256sum)
+package sumfile
+
+import (
+	"encoding/hex" // Пакет для кодирования/декодирования шестнадцатеричных строк
+	"strings"      // Пакет для работы со строками
+)
+
+// EscapeName экранирует имя файла как coreutils: обратная косая черта,
+// перевод строки и возврат каретки заменяются на \\, \n и \r. Второе
+// значение сообщает, потребовалось ли экранирование
+func EscapeName(name string) (string, bool) {
+	if !strings.ContainsAny(name, "\\\n\r") {
+		return name, false
+	}
+	r := strings.NewReplacer("\\", "\\\\", "\n", "\\n", "\r", "\\r")
+	return r.Replace(name), true
+}
+
+// UnescapeName восстанавливает имя файла, экранированное EscapeName
+func UnescapeName(s string) (string, bool) {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' {
+			b.WriteByte(s[i])
+			continue
+		}
+		if i++; i == len(s) {
+			return "", false
+		}
+		switch s[i] {
+		case '\\':
+			b.WriteByte('\\')
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		default:
+			return "", false
+		}
+	}
+	return b.String(), true
+}
+
+// FormatLine формирует строку в формате coreutils: "<хеш>  <файл>" или,
+// в двоичном режиме, "<хеш> *<файл>"; строка с экранированным именем
+// начинается с обратной косой черты
+func FormatLine(hash, path string, binary bool) string {
+	name, escaped := EscapeName(path)
+	prefix, marker := "", " "
+	if escaped {
+		prefix = "\\"
+	}
+	if binary {
+		marker = "*"
+	}
+	return prefix + hash + " " + marker + name
+}
+
+// ParseLine разбирает строку в формате coreutils с хеш-значением длиной
+// hashLen шестнадцатеричных цифр. Завершающий возврат каретки (строки CRLF)
+// отбрасывается
+func ParseLine(line string, hashLen int) (hash, path string, ok bool) {
+	line = strings.TrimSuffix(line, "\r")
+	escaped := strings.HasPrefix(line, "\\")
+	if escaped {
+		line = line[1:]
+	}
+	if len(line) < hashLen+3 || line[hashLen] != ' ' || (line[hashLen+1] != ' ' && line[hashLen+1] != '*') {
+		return "", "", false
+	}
+	hash = strings.ToLower(line[:hashLen])
+	if _, err := hex.DecodeString(hash); err != nil {
+		return "", "", false
+	}
+	path = line[hashLen+2:]
+	if escaped {
+		if path, ok = UnescapeName(path); !ok {
+			return "", "", false
+		}
+	}
+	return hash, path, true
+}
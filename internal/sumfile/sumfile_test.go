@@ -0,0 +1,114 @@
+package sumfile
+
+import (
+	"strings" // Пакет для работы со строками
+	"testing" // Пакет для написания тестов
+)
+
+func TestEscapeName(t *testing.T) {
+	tests := []struct {
+		name    string
+		escaped string
+		changed bool
+	}{
+		{"file.txt", "file.txt", false},
+		{"dir/имя файла", "dir/имя файла", false},
+		{`a\b`, `a\\b`, true},
+		{"a\nb", `a\nb`, true},
+		{"a\rb", `a\rb`, true},
+		{"\\\n\r", `\\\n\r`, true},
+	}
+	for _, tt := range tests {
+		got, changed := EscapeName(tt.name)
+		if got != tt.escaped || changed != tt.changed {
+			t.Errorf("EscapeName(%q) = %q, %v; ожидалось %q, %v", tt.name, got, changed, tt.escaped, tt.changed)
+		}
+		if back, ok := UnescapeName(got); changed && (!ok || back != tt.name) {
+			t.Errorf("UnescapeName(%q) = %q, %v; ожидалось %q", got, back, ok, tt.name)
+		}
+	}
+}
+
+func TestUnescapeNameErrors(t *testing.T) {
+	for _, s := range []string{`a\`, `\`, `a\tb`, `a\x`} {
+		if got, ok := UnescapeName(s); ok {
+			t.Errorf("UnescapeName(%q) = %q, ожидалась ошибка", s, got)
+		}
+	}
+}
+
+func TestFormatLine(t *testing.T) {
+	hash := strings.Repeat("ab", 32)
+	tests := []struct {
+		path   string
+		binary bool
+		want   string
+	}{
+		{"file.txt", false, hash + "  file.txt"},
+		{"file.txt", true, hash + " *file.txt"},
+		{"a\nb", false, `\` + hash + `  a\nb`},
+		{`a\b`, true, `\` + hash + ` *a\\b`},
+	}
+	for _, tt := range tests {
+		if got := FormatLine(hash, tt.path, tt.binary); got != tt.want {
+			t.Errorf("FormatLine(%q, %v) = %q, ожидалось %q", tt.path, tt.binary, got, tt.want)
+		}
+	}
+}
+
+func TestParseLine(t *testing.T) {
+	h64 := strings.Repeat("0123456789abcdef", 4)
+	h128 := strings.Repeat("0123456789abcdef", 8)
+	tests := []struct {
+		name    string
+		line    string
+		hashLen int
+		hash    string
+		path    string
+		ok      bool
+	}{
+		{"текстовый режим", h64 + "  file.txt", 64, h64, "file.txt", true},
+		{"двоичный режим", h64 + " *file.txt", 64, h64, "file.txt", true},
+		{"пробелы в имени", h64 + "  a  b ", 64, h64, "a  b ", true},
+		{"прописные цифры", strings.ToUpper(h64) + "  file.txt", 64, h64, "file.txt", true},
+		{"CRLF", h64 + "  file.txt\r", 64, h64, "file.txt", true},
+		{"экранирование", `\` + h64 + `  a\nb\\c\r`, 64, h64, "a\nb\\c\r", true},
+		{"экранирование, CRLF", `\` + h64 + " *a\\nb\r", 64, h64, "a\nb", true},
+		{"без экранирования", h64 + `  a\nb`, 64, h64, `a\nb`, true},
+		{"streebog512", h128 + "  file.txt", 128, h128, "file.txt", true},
+		{"висящая обратная косая черта", `\` + h64 + `  a\`, 64, "", "", false},
+		{"неизвестная последовательность", `\` + h64 + `  a\tb`, 64, "", "", false},
+		{"короткий хеш", h64[:62] + "  file.txt", 64, "", "", false},
+		{"длинный хеш", h64 + "00  file.txt", 64, "", "", false},
+		{"хеш streebog256 вместо streebog512", h64 + "  file.txt", 128, "", "", false},
+		{"не шестнадцатеричные цифры", strings.Repeat("g", 64) + "  file.txt", 64, "", "", false},
+		{"один пробел", h64 + " file.txt", 64, "", "", false},
+		{"нет имени", h64 + "  ", 64, "", "", false},
+		{"пустая строка", "", 64, "", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hash, path, ok := ParseLine(tt.line, tt.hashLen)
+			if hash != tt.hash || path != tt.path || ok != tt.ok {
+				t.Errorf("ParseLine(%q) = %q, %q, %v; ожидалось %q, %q, %v", tt.line, hash, path, ok, tt.hash, tt.path, tt.ok)
+			}
+		})
+	}
+}
+
+// Строка FormatLine разбирается ParseLine для хеш-значений длиной 256 бит
+// (ГОСТ Р 34.11-94, Стрибог-256) и 512 бит (Стрибог-512)
+func TestLineRoundTrip(t *testing.T) {
+	for _, size := range []int{32, 64} {
+		hash := strings.Repeat("5a", size)
+		for _, path := range []string{"file.txt", "a\nb\\c", "*star", " lead"} {
+			for _, binary := range []bool{false, true} {
+				line := FormatLine(hash, path, binary)
+				gotHash, gotPath, ok := ParseLine(line, len(hash))
+				if !ok || gotHash != hash || gotPath != path {
+					t.Errorf("%q разобрана как %q, %q, %v", line, gotHash, gotPath, ok)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"bytes"       // Пакет для работы с байтовыми срезами
+	"crypto/rand" // Пакет с криптографически стойким генератором случайных чисел
+	"fmt"         // Пакет для форматированного ввода-вывода
+	"os"          // Пакет для работы с операционной системой
+	"strings"     // Пакет для работы со строками
+
+	"github.com/ftomza/gogost/gost3410" // Эллиптические кривые ГОСТ Р 34.10
+
+	"main/gost34102001" // Подпись ГОСТ Р 34.10-2001
+)
+
+// readPassword читает пароль из первой строки файла; пустой путь означает
+// отсутствие пароля
+func readPassword(path string) ([]byte, error) {
+	if path == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	line, _, _ := strings.Cut(string(data), "\n")
+	return []byte(strings.TrimSuffix(line, "\r")), nil
+}
+
+// isPEM сообщает, содержит ли файл ключа блок PEM
+func isPEM(data []byte) bool {
+	return bytes.Contains(data, []byte("-----BEGIN "))
+}
+
+// loadPrivateKey читает закрытый ключ из файла PEM (кривая задана в ключе)
+// или из шестнадцатеричной строки на кривой curveName
+func loadPrivateKey(path, curveName, passwordFile string) (*gost3410.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if isPEM(data) {
+		password, err := readPassword(passwordFile)
+		if err != nil {
+			return nil, err
+		}
+		return gost34102001.DecodePrivateKeyPEM(data, password)
+	}
+	curve, err := gost34102001.CurveByName(curveName)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := readHexFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return gost3410.NewPrivateKey(curve.Curve(), raw)
+}
+
+// loadPublicKey читает открытый ключ из файла PEM (кривая задана в ключе)
+// или из шестнадцатеричной строки на кривой curveName
+func loadPublicKey(path, curveName string) (*gost3410.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if isPEM(data) {
+		return gost34102001.DecodePublicKeyPEM(data)
+	}
+	curve, err := gost34102001.CurveByName(curveName)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := readHexFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return gost3410.NewPublicKey(curve.Curve(), raw)
+}
+
+// writePublicKey записывает открытый ключ в PEM в файл path или, если путь
+// пустой, в стандартный вывод
+func writePublicKey(pub *gost3410.PublicKey, path string) error {
+	data, err := gost34102001.EncodePublicKeyPEM(pub)
+	if err != nil {
+		return err
+	}
+	if path == "" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// cmdKeygen вырабатывает пару ключей и сохраняет закрытый ключ в PKCS#8 PEM.
+// Возвращает код завершения процесса
+func cmdKeygen(args []string) int {
+	fs := newFlagSet("keygen", "keygen -o <ключ.pem> [-pub <открытый.pem>] [-curve <кривая>] [-password-file <файл>]")
+	curveName := fs.String("curve", "cryptopro-a", "набор параметров кривой: "+curveNames())
+	out := fs.String("o", "", "файл закрытого ключа (PEM)")
+	pubOut := fs.String("pub", "", "файл открытого ключа (PEM), необязательно")
+	passwordFile := fs.String("password-file", "", "файл с паролем для защиты закрытого ключа")
+	fs.Parse(args)
+	if *out == "" || fs.NArg() != 0 {
+		fs.Usage()
+		return exitUsage
+	}
+
+	curve, err := gost34102001.CurveByName(*curveName)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Ошибка: %v\n", err)
+		return exitUsage
+	}
+	password, err := readPassword(*passwordFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Ошибка чтения пароля: %v\n", err)
+		return exitError
+	}
+	prv, err := gost34102001.GenerateKey(curve, rand.Reader)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Ошибка: %v\n", err)
+		return exitError
+	}
+	data, err := gost34102001.EncodePrivateKeyPEM(prv, password, rand.Reader)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Ошибка: %v\n", err)
+		return exitError
+	}
+	// Закрытый ключ доступен только владельцу файла
+	if err := os.WriteFile(*out, data, 0o600); err != nil {
+		fmt.Fprintf(os.Stderr, "Ошибка записи ключа: %v\n", err)
+		return exitError
+	}
+	if *pubOut != "" {
+		pub, err := prv.PublicKey()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Ошибка: %v\n", err)
+			return exitError
+		}
+		if err := writePublicKey(pub, *pubOut); err != nil {
+			fmt.Fprintf(os.Stderr, "Ошибка записи открытого ключа: %v\n", err)
+			return exitError
+		}
+	}
+	fmt.Printf("Ключ ГОСТ Р 34.10-2001 (%s) сохранен в %s\n", curve.Name, *out)
+	return exitOK
+}
+
+// cmdPubkey выводит открытый ключ, соответствующий закрытому, в PEM.
+// Возвращает код завершения процесса
+func cmdPubkey(args []string) int {
+	fs := newFlagSet("pubkey", "pubkey -key <ключ> [-curve <кривая>] [-o <открытый.pem>] [-password-file <файл>]")
+	keyPath := fs.String("key", "", "файл закрытого ключа")
+	curveName := fs.String("curve", "cryptopro-a", "набор параметров кривой для ключа в шестнадцатеричном виде: "+curveNames())
+	out := fs.String("o", "", "файл открытого ключа (по умолчанию стандартный вывод)")
+	passwordFile := fs.String("password-file", "", "файл с паролем закрытого ключа")
+	fs.Parse(args)
+	if *keyPath == "" || fs.NArg() != 0 {
+		fs.Usage()
+		return exitUsage
+	}
+
+	if _, err := gost34102001.CurveByName(*curveName); err != nil {
+		fmt.Fprintf(os.Stderr, "Ошибка: %v\n", err)
+		return exitUsage
+	}
+
+	prv, err := loadPrivateKey(*keyPath, *curveName, *passwordFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Ошибка чтения ключа: %v\n", err)
+		return exitError
+	}
+	pub, err := prv.PublicKey()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Ошибка: %v\n", err)
+		return exitError
+	}
+	if err := writePublicKey(pub, *out); err != nil {
+		fmt.Fprintf(os.Stderr, "Ошибка записи открытого ключа: %v\n", err)
+		return exitError
+	}
+	return exitOK
+}
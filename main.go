@@ -2,29 +2,37 @@
 package main
 
 import (
-	"encoding/hex"    // Пакет для кодирования/декодирования шестнадцатеричных строк
-	"fmt"             // Пакет для форматированного ввода-вывода
-	"html/template"   // Пакет для работы с HTML шаблонами
-	"io"              // Пакет для работы с операциями ввода-вывода
-	"main/gost341194" // Импорт пакета с реализацией ГОСТ Р 34.11-94
-	"net/http"        // Пакет для создания HTTP сервера
-	"os"              // Пакет для работы с операционной системой
+	"encoding/hex"  // Пакет для кодирования/декодирования шестнадцатеричных строк
+	"fmt"           // Пакет для форматированного ввода-вывода
+	"html/template" // Пакет для работы с HTML шаблонами
+	"io"            // Пакет для работы с операциями ввода-вывода
+	"net/http"      // Пакет для создания HTTP сервера
+	"os"            // Пакет для работы с операционной системой
+
+	"main/internal/sumfile" // Строки файлов контрольных сумм coreutils
 )
 
 // Структура для хранения данных о результате хеширования
 type HashResult struct {
-	InputText string
-	FileName  string
-	Hash      string
-	Error     string
+	InputText      string
+	FileName       string
+	Hash           string
+	Error          string
+	Algorithm      string      // Имя выбранного алгоритма
+	AlgorithmTitle string      // Название алгоритма, которым вычислен хеш
+	Algorithms     []Algorithm // Алгоритмы для выбора в форме
+	// Отпечатки загруженных сертификатов
+	Certificates []CertThumbprint
 }
 
+const readBufferSize = 64 << 10 // Размер буфера чтения файлов в командной строке
+
 // HTML шаблон для веб-интерфейса
 const htmlTemplate = `
 <!DOCTYPE html>
 <html>
 <head>
-    <title>ГОСТ Р 34.11-94 Хеширование</title>
+    <title>ГОСТ Хеширование</title>
     <meta charset="utf-8">
     <style>
         body {
@@ -47,6 +55,9 @@ const htmlTemplate = `
             margin-bottom: 5px;
             font-weight: bold;
         }
+        select {
+            padding: 6px;
+        }
         textarea {
             width: 100%;
             height: 100px;
@@ -99,11 +110,12 @@ const htmlTemplate = `
 </head>
 <body>
     <div class="container">
-        <h1>Генератор хеша ГОСТ Р 34.11-94</h1>
+        <h1>Генератор хеша ГОСТ Р 34.11-94 / 34.11-2012</h1>
         
         <div class="tabs">
             <div class="tab active" onclick="openTab(event, 'text-tab')">Ввод текста</div>
             <div class="tab" onclick="openTab(event, 'file-tab')">Загрузка файла</div>
+            <div class="tab" onclick="openTab(event, 'cert-tab')">Отпечаток сертификата</div>
         </div>
         
         <div id="text-tab" class="tab-content active">
@@ -112,6 +124,14 @@ const htmlTemplate = `
                     <label for="text">Введите текст для хеширования:</label>
                     <textarea id="text" name="text" required>{{.InputText}}</textarea>
                 </div>
+                <div class="form-group">
+                    <label for="algorithm-text">Алгоритм:</label>
+                    <select id="algorithm-text" name="algorithm">
+                        {{range .Algorithms}}
+                        <option value="{{.Name}}"{{if eq .Name $.Algorithm}} selected{{end}}>{{.Title}}</option>
+                        {{end}}
+                    </select>
+                </div>
                 <button type="submit">Хешировать</button>
             </form>
         </div>
@@ -122,10 +142,28 @@ const htmlTemplate = `
                     <label for="file">Выберите файл для хеширования:</label>
                     <input type="file" id="file" name="file" required>
                 </div>
+                <div class="form-group">
+                    <label for="algorithm-file">Алгоритм:</label>
+                    <select id="algorithm-file" name="algorithm">
+                        {{range .Algorithms}}
+                        <option value="{{.Name}}"{{if eq .Name $.Algorithm}} selected{{end}}>{{.Title}}</option>
+                        {{end}}
+                    </select>
+                </div>
                 <button type="submit">Хешировать файл</button>
             </form>
         </div>
         
+        <div id="cert-tab" class="tab-content">
+            <form action="/thumbprint" method="post" enctype="multipart/form-data">
+                <div class="form-group">
+                    <label for="cert">Выберите сертификат X.509 (PEM или DER, допускается цепочка):</label>
+                    <input type="file" id="cert" name="cert" required>
+                </div>
+                <button type="submit">Вычислить отпечаток</button>
+            </form>
+        </div>
+        
         {{if .Hash}}
         <div class="result">
             {{if .FileName}}
@@ -134,7 +172,21 @@ const htmlTemplate = `
             <h3>Результат хеширования текста:</h3>
             <p><strong>Исходный текст:</strong> {{.InputText}}</p>
             {{end}}
-            <p><strong>ГОСТ Р 34.11-94 хеш:</strong> {{.Hash}}</p>
+            <p><strong>{{.AlgorithmTitle}} хеш:</strong> {{.Hash}}</p>
+        </div>
+        {{end}}
+        
+        {{if .Certificates}}
+        <div class="result">
+            <h3>Отпечатки сертификатов из файла: {{.FileName}}</h3>
+            {{range .Certificates}}
+            <p><strong>Владелец:</strong> {{.Subject}}</p>
+            <p><strong>Издатель:</strong> {{.Issuer}}</p>
+            <p><strong>Серийный номер:</strong> {{.Serial}}</p>
+            <p><strong>Отпечаток ГОСТ Р 34.11-94:</strong> {{.Cert}}</p>
+            <p><strong>Отпечаток открытого ключа:</strong> {{.PublicKey}}</p>
+            <hr>
+            {{end}}
         </div>
         {{end}}
         
@@ -175,7 +227,7 @@ func indexHandler(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Ошибка шаблона: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
-	tmpl.Execute(w, &HashResult{})
+	tmpl.Execute(w, &HashResult{Algorithms: algorithms})
 }
 
 // Функция для хеширования текста
@@ -187,15 +239,24 @@ func hashTextHandler(w http.ResponseWriter, r *http.Request) {
 
 	text := r.FormValue("text")
 
+	alg, err := algorithmByName(r.FormValue("algorithm"))
+	if err != nil {
+		renderError(w, err.Error())
+		return
+	}
+
 	// Создаем хеш
-	h := gost341194.New(gost341194.SboxDefault)
+	h := alg.New()
 	h.Write([]byte(text))
 	hash := h.Sum(nil)
 
 	// Формируем результат
 	result := &HashResult{
-		InputText: text,
-		Hash:      hex.EncodeToString(hash),
+		InputText:      text,
+		Hash:           hex.EncodeToString(hash),
+		Algorithm:      alg.Name,
+		AlgorithmTitle: alg.Title,
+		Algorithms:     algorithms,
 	}
 
 	// Отображаем страницу с результатом
@@ -218,8 +279,14 @@ func hashFileHandler(w http.ResponseWriter, r *http.Request) {
 	}
 	defer file.Close()
 
+	alg, err := algorithmByName(r.FormValue("algorithm"))
+	if err != nil {
+		renderError(w, err.Error())
+		return
+	}
+
 	// Создаем хеш
-	h := gost341194.New(gost341194.SboxDefault)
+	h := alg.New()
 
 	// Копируем содержимое файла в хеш
 	_, err = io.Copy(h, file)
@@ -232,8 +299,11 @@ func hashFileHandler(w http.ResponseWriter, r *http.Request) {
 
 	// Формируем результат
 	result := &HashResult{
-		FileName: header.Filename,
-		Hash:     hex.EncodeToString(hash),
+		FileName:       header.Filename,
+		Hash:           hex.EncodeToString(hash),
+		Algorithm:      alg.Name,
+		AlgorithmTitle: alg.Title,
+		Algorithms:     algorithms,
 	}
 
 	// Отображаем страницу с результатом
@@ -244,50 +314,103 @@ func hashFileHandler(w http.ResponseWriter, r *http.Request) {
 // Функция для отображения ошибок
 func renderError(w http.ResponseWriter, errMessage string) {
 	result := &HashResult{
-		Error: errMessage,
+		Error:      errMessage,
+		Algorithms: algorithms,
 	}
 
 	tmpl, _ := template.New("index").Parse(htmlTemplate)
 	tmpl.Execute(w, result)
 }
 
-// computeFileHash вычисляет хеш для указанного файла
-func computeFileHash(filePath string) (string, error) {
-	data, err := os.ReadFile(filePath)
-	if err != nil {
-		return "", err
+// computeFileHash вычисляет хеш для указанного файла алгоритмом alg, читая
+// его порциями по readBufferSize байт, и возвращает число прочитанных байтов.
+// Кроме обычных файлов поддерживаются блочные устройства и именованные
+// каналы; путь "-" означает стандартный ввод
+func computeFileHash(filePath string, alg *Algorithm) (string, int64, error) {
+	file := os.Stdin
+	if filePath != "-" {
+		f, err := os.Open(filePath)
+		if err != nil {
+			return "", 0, err
+		}
+		defer f.Close()
+		file = f
+	}
+	if info, err := file.Stat(); err == nil && info.IsDir() {
+		return "", 0, fmt.Errorf("%s является каталогом", filePath)
 	}
 
-	h := gost341194.New(gost341194.SboxDefault)
-	h.Write(data)
-	hash := h.Sum(nil)
+	h := alg.New()
+	buf := make([]byte, readBufferSize)
+	var total int64
+	for {
+		n, err := file.Read(buf)
+		if n > 0 {
+			h.Write(buf[:n])
+			total += int64(n)
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", total, fmt.Errorf("ошибка чтения после %d байт: %w", total, err)
+		}
+	}
 
-	return hex.EncodeToString(hash), nil
+	return hex.EncodeToString(h.Sum(nil)), total, nil
 }
 
-// main запускает веб-сервер или выполняет хеширование из командной строки
-func main() {
-	// Если указан путь к файлу в аргументах, вычисляем хеш файла
-	if len(os.Args) > 1 {
-		filePath := os.Args[1]
-		hash, err := computeFileHash(filePath)
+// hashFiles выводит хеш-значения файлов из аргументов командной строки
+// (в формате coreutils при sum). Ошибки выводятся в стандартный поток ошибок
+// и не прерывают обработку; при нескольких файлах в конце выводится итог.
+// Возвращает код завершения
+func hashFiles(args []string, alg *Algorithm, opt walkOptions, sum, binary bool) int {
+	entries := collectFiles(args, opt)
+	failed := 0
+	for _, f := range entries {
+		if f.err != nil {
+			fmt.Fprintf(os.Stderr, "Ошибка: %v\n", f.err)
+			failed++
+			continue
+		}
+		hash, size, err := computeFileHash(f.path, alg)
 		if err != nil {
-			fmt.Printf("Ошибка: %v\n", err)
-		} else {
-			fmt.Printf("ГОСТ Р 34.11-94 хеш для файла %s: %s\n", filePath, hash)
+			fmt.Fprintf(os.Stderr, "Ошибка: %s: %v\n", f.path, err)
+			failed++
+			continue
 		}
-		return
+		if sum {
+			fmt.Println(sumfile.FormatLine(hash, f.path, binary))
+			continue
+		}
+		fmt.Printf("%s хеш для файла %s (%d байт): %s\n", alg.Title, f.path, size, hash)
 	}
+	if len(entries) > 1 {
+		fmt.Fprintf(os.Stderr, "Итого: файлов: %d, успешно: %d, ошибок: %d\n", len(entries), len(entries)-failed, failed)
+	}
+	if failed > 0 {
+		return exitError
+	}
+	return exitOK
+}
 
-	// Если аргументов нет, запускаем веб-сервер
-	fmt.Println("Запуск веб-сервера на http://localhost:8080")
-
-	http.HandleFunc("/", indexHandler)
-	http.HandleFunc("/hash", hashTextHandler)
-	http.HandleFunc("/hash-file", hashFileHandler)
-
-	err := http.ListenAndServe(":8080", nil)
-	if err != nil {
-		fmt.Printf("Ошибка запуска сервера: %v\n", err)
+// main выполняет команду, заданную первым аргументом командной строки
+func main() {
+	// Без команды выводится справка: веб-сервер запускается только командой serve
+	if len(os.Args) < 2 {
+		printUsage(os.Stderr)
+		os.Exit(exitUsage)
+	}
+	switch name := os.Args[1]; name {
+	case "help", "-h", "-help", "--help":
+		printUsage(os.Stdout)
+	default:
+		cmd := commandByName(name)
+		if cmd == nil {
+			fmt.Fprintf(os.Stderr, "Ошибка: неизвестная команда %s\n\n", name)
+			printUsage(os.Stderr)
+			os.Exit(exitUsage)
+		}
+		os.Exit(cmd.run(os.Args[2:]))
 	}
 }
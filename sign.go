@@ -0,0 +1,126 @@
+package main
+
+import (
+	"crypto/rand"  // Пакет с криптографически стойким генератором случайных чисел
+	"encoding/hex" // Пакет для кодирования/декодирования шестнадцатеричных строк
+	"fmt"          // Пакет для форматированного ввода-вывода
+	"os"           // Пакет для работы с операционной системой
+	"strings"      // Пакет для работы со строками
+
+	"main/gost34102001" // Подпись ГОСТ Р 34.10-2001
+)
+
+// readHexFile читает файл с шестнадцатеричной строкой и декодирует ее
+func readHexFile(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return hex.DecodeString(strings.TrimSpace(string(data)))
+}
+
+// curveNames возвращает имена наборов параметров кривых через запятую
+func curveNames() string {
+	var names []string
+	for _, c := range gost34102001.Curves() {
+		names = append(names, c.Name)
+	}
+	return strings.Join(names, ", ")
+}
+
+// cmdSign подписывает файл и сохраняет отделенную подпись в шестнадцатеричном
+// виде. Возвращает код завершения процесса
+func cmdSign(args []string) int {
+	fs := newFlagSet("sign", "sign -key <ключ> [-curve <кривая>] [-password-file <файл>] [-o <подпись>] <файл>")
+	keyPath := fs.String("key", "", "файл закрытого ключа (PKCS#8 PEM или 32 байта в шестнадцатеричном виде, младший байт первый)")
+	curveName := fs.String("curve", "cryptopro-a", "набор параметров кривой для ключа в шестнадцатеричном виде: "+curveNames())
+	out := fs.String("o", "", "файл подписи (по умолчанию <файл>.sig)")
+	passwordFile := fs.String("password-file", "", "файл с паролем закрытого ключа")
+	fs.Parse(args)
+	if *keyPath == "" || fs.NArg() != 1 {
+		fs.Usage()
+		return exitUsage
+	}
+
+	if _, err := gost34102001.CurveByName(*curveName); err != nil {
+		fmt.Fprintf(os.Stderr, "Ошибка: %v\n", err)
+		return exitUsage
+	}
+	filePath := fs.Arg(0)
+	if *out == "" {
+		*out = filePath + ".sig"
+	}
+
+	prv, err := loadPrivateKey(*keyPath, *curveName, *passwordFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Ошибка чтения ключа: %v\n", err)
+		return exitError
+	}
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Ошибка: %v\n", err)
+		return exitError
+	}
+	defer file.Close()
+	sig, err := gost34102001.Sign(prv, file, rand.Reader)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Ошибка: %v\n", err)
+		return exitError
+	}
+
+	if err := os.WriteFile(*out, []byte(hex.EncodeToString(sig)+"\n"), 0o644); err != nil {
+		fmt.Fprintf(os.Stderr, "Ошибка записи подписи: %v\n", err)
+		return exitError
+	}
+	fmt.Printf("Подпись ГОСТ Р 34.10-2001 для файла %s сохранена в %s\n", filePath, *out)
+	return exitOK
+}
+
+// cmdVerify проверяет отделенную подпись файла. Возвращает код завершения
+// процесса: 0 - подпись верна, 3 - подпись неверна, 1 - ошибка чтения ключа,
+// подписи или файла, 2 - неверные аргументы
+func cmdVerify(args []string) int {
+	fs := newFlagSet("verify", "verify -pub <ключ> [-curve <кривая>] [-sig <подпись>] <файл>")
+	pubPath := fs.String("pub", "", "файл открытого ключа (PEM или 64 байта в шестнадцатеричном виде, младший байт первый)")
+	curveName := fs.String("curve", "cryptopro-a", "набор параметров кривой для ключа в шестнадцатеричном виде: "+curveNames())
+	sigPath := fs.String("sig", "", "файл подписи (по умолчанию <файл>.sig)")
+	fs.Parse(args)
+	if *pubPath == "" || fs.NArg() != 1 {
+		fs.Usage()
+		return exitUsage
+	}
+
+	if _, err := gost34102001.CurveByName(*curveName); err != nil {
+		fmt.Fprintf(os.Stderr, "Ошибка: %v\n", err)
+		return exitUsage
+	}
+	filePath := fs.Arg(0)
+	if *sigPath == "" {
+		*sigPath = filePath + ".sig"
+	}
+
+	pub, err := loadPublicKey(*pubPath, *curveName)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Ошибка чтения ключа: %v\n", err)
+		return exitError
+	}
+	sig, err := readHexFile(*sigPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Ошибка чтения подписи: %v\n", err)
+		return exitError
+	}
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Ошибка: %v\n", err)
+		return exitError
+	}
+	defer file.Close()
+	if err := gost34102001.Verify(pub, file, sig); err != nil {
+		fmt.Printf("Подпись файла %s НЕВЕРНА: %v\n", filePath, err)
+		return exitMismatch
+	}
+	fmt.Printf("Подпись файла %s верна\n", filePath)
+	return exitOK
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"bufio"  // Пакет для построчного чтения
+	"errors" // Пакет для создания ошибок
+	"fmt"    // Пакет для форматированного ввода-вывода
+	"io/fs"  // Пакет с общими ошибками файловой системы
+	"os"     // Пакет для работы с операционной системой
+
+	"main/internal/sumfile" // Строки файлов контрольных сумм coreutils
+)
+
+const maxSumLineSize = 1 << 20 // Наибольшая длина строки файла контрольных сумм
+
+// checkOptions задает поведение проверки файлов контрольных сумм (-c)
+type checkOptions struct {
+	quiet         bool // Не выводить строки OK
+	status        bool // Ничего не выводить, результат только в коде завершения
+	strict        bool // Считать ошибкой строки неверного формата
+	ignoreMissing bool // Пропускать отсутствующие файлы
+}
+
+// sumFileName возвращает имя файла контрольных сумм для сообщений
+func sumFileName(path string) string {
+	if path == "-" {
+		return "стандартный ввод"
+	}
+	return path
+}
+
+// checkStats - итоги проверки по файлам контрольных сумм
+type checkStats struct {
+	verified   int // Проверено файлов
+	failed     int // Хеш-значение не совпало
+	unreadable int // Файл не удалось прочитать
+	malformed  int // Строк неверного формата
+	errors     int // Файлов контрольных сумм, которые не удалось обработать
+}
+
+// add прибавляет итоги другой проверки
+func (s *checkStats) add(o checkStats) {
+	s.verified += o.verified
+	s.failed += o.failed
+	s.unreadable += o.unreadable
+	s.malformed += o.malformed
+	s.errors += o.errors
+}
+
+// checkSums проверяет файлы по спискам контрольных сумм sumFiles ("-" -
+// стандартный ввод) и возвращает код завершения: exitMismatch, если хотя бы
+// одно хеш-значение не совпало, exitError при ошибках чтения (и строках
+// неверного формата с strict), иначе exitOK
+func checkSums(sumFiles []string, alg *Algorithm, opt checkOptions) int {
+	var total checkStats
+	hashLen := 2 * alg.New().Size()
+	for _, sumFile := range sumFiles {
+		total.add(checkSumFile(sumFile, hashLen, alg, opt))
+	}
+	if len(sumFiles) > 1 && !opt.status {
+		fmt.Fprintf(os.Stderr, "Итого: проверено файлов: %d, не совпало: %d, не прочитано: %d\n",
+			total.verified, total.failed, total.unreadable)
+	}
+	switch {
+	case total.failed > 0:
+		return exitMismatch
+	case total.unreadable > 0 || total.errors > 0 || (opt.strict && total.malformed > 0):
+		return exitError
+	}
+	return exitOK
+}
+
+// checkSumFile проверяет файлы по одному списку контрольных сумм
+func checkSumFile(sumFile string, hashLen int, alg *Algorithm, opt checkOptions) checkStats {
+	var st checkStats
+	in := os.Stdin
+	if sumFile != "-" {
+		f, err := os.Open(sumFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Ошибка: %v\n", err)
+			st.errors++
+			return st
+		}
+		defer f.Close()
+		in = f
+	}
+
+	lines := 0
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 64<<10), maxSumLineSize)
+	for scanner.Scan() {
+		lines++
+		want, path, ok := sumfile.ParseLine(scanner.Text(), hashLen)
+		if !ok {
+			st.malformed++
+			continue
+		}
+		name, escaped := sumfile.EscapeName(path)
+		if escaped {
+			name = "\\" + name
+		}
+
+		got, _, err := computeFileHash(path, alg)
+		if err != nil {
+			if opt.ignoreMissing && errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			st.unreadable++
+			if !opt.status {
+				fmt.Fprintf(os.Stderr, "Ошибка: %v\n", err)
+				fmt.Printf("%s: FAILED open or read\n", name)
+			}
+			continue
+		}
+		st.verified++
+		if got != want {
+			st.failed++
+			if !opt.status {
+				fmt.Printf("%s: FAILED\n", name)
+			}
+		} else if !opt.status && !opt.quiet {
+			fmt.Printf("%s: OK\n", name)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		fmt.Fprintf(os.Stderr, "Ошибка: %s: %v\n", sumFileName(sumFile), err)
+		st.errors++
+		return st
+	}
+
+	if lines == st.malformed {
+		if !opt.status {
+			fmt.Fprintf(os.Stderr, "Ошибка: %s: не найдено строк с контрольными суммами %s\n", sumFileName(sumFile), alg.Name)
+		}
+		st.errors++
+		return st
+	}
+	if !opt.status {
+		if st.malformed > 0 {
+			fmt.Fprintf(os.Stderr, "ПРЕДУПРЕЖДЕНИЕ: строк неверного формата: %d\n", st.malformed)
+		}
+		if st.unreadable > 0 {
+			fmt.Fprintf(os.Stderr, "ПРЕДУПРЕЖДЕНИЕ: не удалось прочитать файлов: %d\n", st.unreadable)
+		}
+		if st.failed > 0 {
+			fmt.Fprintf(os.Stderr, "ПРЕДУПРЕЖДЕНИЕ: контрольных сумм НЕ совпало: %d\n", st.failed)
+		}
+	}
+	if opt.ignoreMissing && st.verified == 0 && st.unreadable == 0 {
+		if !opt.status {
+			fmt.Fprintf(os.Stderr, "Ошибка: %s: ни один файл не проверен\n", sumFileName(sumFile))
+		}
+		st.errors++
+	}
+	return st
+}
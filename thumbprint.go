@@ -0,0 +1,175 @@
+package main
+
+import (
+	"bytes"         // Пакет для работы с байтовыми срезами
+	"crypto/x509"   // Пакет для разбора сертификатов X.509
+	"encoding/pem"  // Пакет для декодирования данных в формате PEM
+	"errors"        // Пакет для создания ошибок
+	"fmt"           // Пакет для форматированного ввода-вывода
+	"html/template" // Пакет для работы с HTML шаблонами
+	"io"            // Пакет для работы с операциями ввода-вывода
+	"net/http"      // Пакет для создания HTTP сервера
+	"os"            // Пакет для работы с операционной системой
+	"strings"       // Пакет для работы со строками
+
+	"main/gost341194" // Импорт пакета с реализацией ГОСТ Р 34.11-94
+)
+
+const maxCertFileSize = 4 << 20 // Наибольший размер загружаемого файла сертификатов
+
+var (
+	errNoCertificates = errors.New("файл не содержит сертификатов")
+	errCertFileSize   = errors.New("файл сертификатов слишком большой")
+)
+
+// CertThumbprint описывает сертификат и его отпечатки ГОСТ Р 34.11-94
+type CertThumbprint struct {
+	Subject   string // Владелец сертификата
+	Issuer    string // Издатель сертификата
+	Serial    string // Серийный номер в шестнадцатеричном виде
+	Cert      string // Отпечаток DER-кодировки сертификата
+	PublicKey string // Отпечаток SubjectPublicKeyInfo
+}
+
+// thumbprint вычисляет хеш ГОСТ Р 34.11-94 с параметрами CryptoPro и
+// записывает его как CryptoPro: байты в шестнадцатеричном виде прописными
+// буквами через пробел
+func thumbprint(der []byte) string {
+	h := gost341194.New(&gost341194.SboxIdGostR341194CryptoProParamSet)
+	h.Write(der)
+	sum := h.Sum(nil)
+	parts := make([]string, len(sum))
+	for i, b := range sum {
+		parts[i] = fmt.Sprintf("%02X", b)
+	}
+	return strings.Join(parts, " ")
+}
+
+// parseCertificates разбирает все сертификаты из PEM (блоки CERTIFICATE,
+// остальные пропускаются) или из одного либо нескольких подряд идущих DER
+func parseCertificates(data []byte) ([]*x509.Certificate, error) {
+	if !bytes.Contains(data, []byte("-----BEGIN ")) {
+		certs, err := x509.ParseCertificates(data)
+		if err != nil {
+			return nil, err
+		}
+		if len(certs) == 0 {
+			return nil, errNoCertificates
+		}
+		return certs, nil
+	}
+
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, errNoCertificates
+	}
+	return certs, nil
+}
+
+// certThumbprints вычисляет отпечатки всех сертификатов из data
+func certThumbprints(data []byte) ([]CertThumbprint, error) {
+	certs, err := parseCertificates(data)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]CertThumbprint, len(certs))
+	for i, cert := range certs {
+		result[i] = CertThumbprint{
+			Subject:   cert.Subject.String(),
+			Issuer:    cert.Issuer.String(),
+			Serial:    fmt.Sprintf("%X", cert.SerialNumber),
+			Cert:      thumbprint(cert.Raw),
+			PublicKey: thumbprint(cert.RawSubjectPublicKeyInfo),
+		}
+	}
+	return result, nil
+}
+
+// cmdThumbprint выводит отпечатки сертификатов из указанных файлов.
+// Возвращает код завершения процесса
+func cmdThumbprint(args []string) int {
+	fs := newFlagSet("thumbprint", "thumbprint <сертификат>...")
+	fs.Parse(args)
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return exitUsage
+	}
+
+	code := exitOK
+	for _, path := range fs.Args() {
+		data, err := os.ReadFile(path)
+		if err == nil {
+			var prints []CertThumbprint
+			if prints, err = certThumbprints(data); err == nil {
+				for _, p := range prints {
+					fmt.Printf("Файл: %s\n", path)
+					fmt.Printf("  Владелец: %s\n", p.Subject)
+					fmt.Printf("  Издатель: %s\n", p.Issuer)
+					fmt.Printf("  Серийный номер: %s\n", p.Serial)
+					fmt.Printf("  Отпечаток ГОСТ Р 34.11-94: %s\n", p.Cert)
+					fmt.Printf("  Отпечаток открытого ключа: %s\n", p.PublicKey)
+				}
+				continue
+			}
+		}
+		fmt.Fprintf(os.Stderr, "Ошибка: %s: %v\n", path, err)
+		code = exitError
+	}
+	return code
+}
+
+// Функция для вычисления отпечатков загруженных сертификатов
+func thumbprintHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	file, header, err := r.FormFile("cert")
+	if err != nil {
+		renderError(w, "Не удалось получить файл: "+err.Error())
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, maxCertFileSize+1))
+	if err != nil {
+		renderError(w, "Ошибка при чтении файла: "+err.Error())
+		return
+	}
+	if len(data) > maxCertFileSize {
+		renderError(w, errCertFileSize.Error())
+		return
+	}
+	prints, err := certThumbprints(data)
+	if err != nil {
+		renderError(w, "Ошибка разбора сертификата: "+err.Error())
+		return
+	}
+
+	// Формируем результат
+	result := &HashResult{
+		FileName:     header.Filename,
+		Certificates: prints,
+		Algorithms:   algorithms,
+	}
+
+	// Отображаем страницу с результатом
+	tmpl, _ := template.New("index").Parse(htmlTemplate)
+	tmpl.Execute(w, result)
+}